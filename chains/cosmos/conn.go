@@ -0,0 +1,50 @@
+package cosmos
+
+import (
+	"github.com/gjermundgaraba/libibc/utils"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// getGRPC returns the chain's gRPC connection, which is created on first use and shared by all calls.
+// Calls fail over between the configured gRPC endpoints.
+func (c *Cosmos) getGRPC() (grpc.ClientConnInterface, error) {
+	if c.grpcEndpoints == nil {
+		return nil, errors.Errorf("no grpc address configured for chain %s", c.ChainID)
+	}
+
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	if c.grpcConn == nil {
+		c.grpcConn = utils.NewFailoverConn(c.grpcEndpoints, c.dialGRPC)
+	}
+
+	return c.grpcConn, nil
+}
+
+// Close implements network.Chain.
+// It also stops the websocket clients of any subscriptions that are still running.
+func (c *Cosmos) Close() error {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	if c.rpcEndpoints != nil {
+		c.rpcEndpoints.LogStats()
+	}
+	c.rpcClient = nil
+
+	for rpcClient := range c.subscriptionClients {
+		c.stopSubscriptionClient(rpcClient)
+	}
+	c.subscriptionClients = nil
+
+	if c.grpcConn == nil {
+		return nil
+	}
+
+	err := c.grpcConn.Close()
+	c.grpcConn = nil
+
+	return err
+}
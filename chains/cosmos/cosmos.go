@@ -3,41 +3,134 @@ package cosmos
 import (
 	"context"
 	"math/big"
+	"sync"
 
+	sdkmath "cosmossdk.io/math"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/gjermundgaraba/libibc/chains/network"
 	"github.com/gjermundgaraba/libibc/utils"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-var _ network.Chain = &Cosmos{}
+const (
+	DefaultBech32Prefix = "cosmos"
+
+	KeyTypeSecp256k1    = "secp256k1"
+	KeyTypeEthSecp256k1 = "eth_secp256k1"
+)
+
+var (
+	_ network.Chain           = &Cosmos{}
+	_ network.BatchTransferer = &Cosmos{}
+)
 
 type Cosmos struct {
 	ChainID string
 	Clients map[string]network.ClientCounterparty
 	Wallets map[string]Wallet
 
-	grpcAddr string
-	codec    codec.Codec
-	logger   *zap.Logger
+	grpcEndpoints *utils.EndpointPool
+	rpcEndpoints  *utils.EndpointPool
+	bech32Prefix  string
+	keyType       string
+	codec         codec.Codec
+	logger        *zap.Logger
+
+	dialGRPC func(addr string) (*grpc.ClientConn, error)
+
+	// connMu guards the connections, which are created on first use and reused until Close
+	connMu    sync.Mutex
+	grpcConn  *utils.FailoverConn
+	rpcClient *rpchttp.HTTP
+	// subscriptionClients are the running websocket clients of the event subscriptions, each owned by its subscription
+	subscriptionClients map[*rpchttp.HTTP]struct{}
+}
+
+// NewCosmos creates a new Cosmos chain. The bech32 prefix defaults to "cosmos" and the key type to secp256k1 if left empty.
+func NewCosmos(logger *zap.Logger, chainID string, grpc string, rpc string, bech32Prefix string, keyType string) (*Cosmos, error) {
+	grpcEndpoints, err := singleEndpoint(logger, chainID+" grpc", grpc)
+	if err != nil {
+		return nil, err
+	}
+	rpcEndpoints, err := singleEndpoint(logger, chainID+" rpc", rpc)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCosmosWithEndpoints(logger, chainID, grpcEndpoints, rpcEndpoints, bech32Prefix, keyType)
 }
 
-func NewCosmos(logger *zap.Logger, chainID string, grpc string) (*Cosmos, error) {
+// NewCosmosWithEndpoints creates a new Cosmos chain that fails over between the gRPC and RPC endpoints of the pools.
+// The rpc endpoints are optional (nil), and so are the gRPC endpoints for a chain that is only used for wallets.
+func NewCosmosWithEndpoints(
+	logger *zap.Logger,
+	chainID string,
+	grpcEndpoints *utils.EndpointPool,
+	rpcEndpoints *utils.EndpointPool,
+	bech32Prefix string,
+	keyType string,
+) (*Cosmos, error) {
+	if bech32Prefix == "" {
+		bech32Prefix = DefaultBech32Prefix
+	}
+
+	switch keyType {
+	case "":
+		keyType = KeyTypeSecp256k1
+	case KeyTypeSecp256k1, KeyTypeEthSecp256k1:
+	default:
+		return nil, errors.Errorf("unsupported key type %s (supported: %s, %s)", keyType, KeyTypeSecp256k1, KeyTypeEthSecp256k1)
+	}
+
 	codec := SetupCodec()
 	return &Cosmos{
 		ChainID: chainID,
 		Clients: make(map[string]network.ClientCounterparty),
 		Wallets: make(map[string]Wallet),
 
-		grpcAddr: grpc,
-		codec:    codec,
-		logger:   logger,
+		grpcEndpoints: grpcEndpoints,
+		rpcEndpoints:  rpcEndpoints,
+		dialGRPC:      utils.GetGRPC,
+		bech32Prefix:  bech32Prefix,
+		keyType:       keyType,
+		codec:         codec,
+		logger:        logger,
 	}, nil
 }
 
+// SetGRPCConfig sets the transport (TLS and auth headers) used to connect to the gRPC endpoints.
+// It only applies to connections made after it is called, so it should be set before the chain is used.
+func (c *Cosmos) SetGRPCConfig(grpcConfig utils.GRPCConfig) error {
+	dialGRPC, err := grpcConfig.Dialer()
+	if err != nil {
+		return errors.Wrapf(err, "invalid grpc config for chain %s", c.ChainID)
+	}
+
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	c.dialGRPC = dialGRPC
+
+	return nil
+}
+
+// singleEndpoint returns a pool with just the address, or nil if it is empty
+func singleEndpoint(logger *zap.Logger, name string, addr string) (*utils.EndpointPool, error) {
+	if addr == "" {
+		return nil, nil
+	}
+
+	return utils.NewEndpointPool(logger, name, []string{addr}, utils.SelectionPriority)
+}
+
 // GetChainID implements network.Chain.
 func (c *Cosmos) GetChainID() string {
 	return c.ChainID
@@ -52,10 +145,19 @@ func (c *Cosmos) GetClients() map[string]network.ClientCounterparty {
 	return c.Clients
 }
 
+// ValidateAddress checks that the address is a valid bech32 account address with the chain's prefix.
+func (c *Cosmos) ValidateAddress(address string) error {
+	if _, err := sdk.GetFromBech32(address, c.bech32Prefix); err != nil {
+		return errors.Wrapf(err, "invalid address %s for chain %s", address, c.ChainID)
+	}
+
+	return nil
+}
+
 func (c *Cosmos) QueryTx(ctx context.Context, txHash string) (*txtypes.GetTxResponse, error) {
-	grpcConn, err := utils.GetGRPC(c.grpcAddr)
+	grpcConn, err := c.getGRPC()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get grpc connection")
+		return nil, err
 	}
 	txClient := txtypes.NewServiceClient(grpcConn)
 	txResponse, err := txClient.GetTx(ctx, &txtypes.GetTxRequest{Hash: txHash})
@@ -67,9 +169,9 @@ func (c *Cosmos) QueryTx(ctx context.Context, txHash string) (*txtypes.GetTxResp
 
 // GetBalance implements network.Chain.
 func (c *Cosmos) GetBalance(ctx context.Context, address string, denom string) (*big.Int, error) {
-	grpcConn, err := utils.GetGRPC(c.grpcAddr)
+	grpcConn, err := c.getGRPC()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get grpc connection")
+		return nil, err
 	}
 
 	bankClient := banktypes.NewQueryClient(grpcConn)
@@ -81,5 +183,88 @@ func (c *Cosmos) GetBalance(ctx context.Context, address string, denom string) (
 		return nil, errors.Wrapf(err, "failed to query balance for address %s and denom %s", address, denom)
 	}
 
-	return new(big.Int).SetInt64(resp.Balance.Amount.Int64()), nil
+	return resp.Balance.Amount.BigInt(), nil
+}
+
+// GetAllBalances implements network.Chain.
+func (c *Cosmos) GetAllBalances(ctx context.Context, address string) (map[string]*big.Int, error) {
+	grpcConn, err := c.getGRPC()
+	if err != nil {
+		return nil, err
+	}
+
+	bankClient := banktypes.NewQueryClient(grpcConn)
+	balances := make(map[string]*big.Int)
+	var nextKey []byte
+	for {
+		resp, err := bankClient.AllBalances(ctx, &banktypes.QueryAllBalancesRequest{
+			Address:    address,
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to query all balances for address %s", address)
+		}
+
+		for _, coin := range resp.Balances {
+			balances[coin.Denom] = coin.Amount.BigInt()
+		}
+
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			break
+		}
+		nextKey = resp.Pagination.NextKey
+	}
+
+	return balances, nil
+}
+
+// GetTokenMetadata implements network.Chain.
+// The decimals are the exponent of the display unit in the bank denom metadata.
+// Denoms without metadata (common for IBC denoms) are treated as having 0 decimals, with the denom as the symbol.
+func (c *Cosmos) GetTokenMetadata(ctx context.Context, denom string) (network.TokenMetadata, error) {
+	grpcConn, err := c.getGRPC()
+	if err != nil {
+		return network.TokenMetadata{}, err
+	}
+
+	bankClient := banktypes.NewQueryClient(grpcConn)
+	resp, err := bankClient.DenomMetadata(ctx, &banktypes.QueryDenomMetadataRequest{Denom: denom})
+	if status.Code(err) == codes.NotFound {
+		return network.TokenMetadata{Denom: denom}, nil
+	}
+	if err != nil {
+		return network.TokenMetadata{}, errors.Wrapf(err, "failed to query denom metadata for %s", denom)
+	}
+
+	return tokenMetadataFromBank(denom, resp.Metadata), nil
+}
+
+// tokenMetadataFromBank converts bank denom metadata, using the display unit for the decimals and symbol.
+func tokenMetadataFromBank(denom string, metadata banktypes.Metadata) network.TokenMetadata {
+	tokenMetadata := network.TokenMetadata{Denom: denom, Symbol: metadata.Symbol}
+	for _, unit := range metadata.DenomUnits {
+		if unit.Denom == metadata.Display {
+			tokenMetadata.Decimals = uint8(unit.Exponent)
+			if tokenMetadata.Symbol == "" {
+				tokenMetadata.Symbol = unit.Denom
+			}
+		}
+	}
+
+	return tokenMetadata
+}
+
+// toSDKInt converts an amount to an sdkmath.Int, without going through int64.
+func toSDKInt(amount *big.Int) (sdkmath.Int, error) {
+	if amount == nil {
+		return sdkmath.Int{}, errors.New("amount is nil")
+	}
+	if amount.Sign() < 0 {
+		return sdkmath.Int{}, errors.Errorf("amount must not be negative: %s", amount.String())
+	}
+	if amount.BitLen() > sdkmath.MaxBitLen {
+		return sdkmath.Int{}, errors.Errorf("amount %s is out of bounds (max %d bits)", amount.String(), sdkmath.MaxBitLen)
+	}
+
+	return sdkmath.NewIntFromBigInt(amount), nil
 }
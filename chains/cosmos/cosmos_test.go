@@ -3,6 +3,7 @@ package cosmos
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -11,7 +12,7 @@ import (
 
 const (
 	// TestCosmosGRPC is the gRPC address of the Cosmos node
-	TestCosmosGRPC  = "eureka-devnet-node-01-grpc.dev.skip.build:443"
+	TestCosmosGRPC  = "https://eureka-devnet-node-01-grpc.dev.skip.build:443"
 	TestTxHashIBCV1 = "C2B9030069B1172A9685EC710D661D61462D69AC06E90582330013C76AB1F23C"
 	TestTxHashIBCV2 = "096ED04AB0A2B0169F16703900A8AA7F3915DBAFA359166EE8DD07B397290F8E"
 )
@@ -23,7 +24,7 @@ func TestGetPacketV1(t *testing.T) {
 	testLogger, _ := zap.NewDevelopment()
 
 	// Create a new Cosmos instance
-	cosmos, err := NewCosmos(testLogger, "test-chain-id", TestCosmosGRPC)
+	cosmos, err := NewCosmos(testLogger, "test-chain-id", TestCosmosGRPC, "", "", "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -47,7 +48,7 @@ func TestGetPacketV2(t *testing.T) {
 	testLogger, _ := zap.NewDevelopment()
 
 	// Create a new Cosmos instance
-	cosmos, err := NewCosmos(testLogger, "test-chain-id", TestCosmosGRPC)
+	cosmos, err := NewCosmos(testLogger, "test-chain-id", TestCosmosGRPC, "", "", "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -63,3 +64,32 @@ func TestGetPacketV2(t *testing.T) {
 	require.Len(t, packets, 1)
 	fmt.Printf("Packets: %+v\n", packets)
 }
+
+func TestGetRPCClientWithoutAddress(t *testing.T) {
+	testLogger, _ := zap.NewDevelopment()
+	cosmos, err := NewCosmos(testLogger, "test-chain-id", TestCosmosGRPC, "", "", "")
+	require.NoError(t, err)
+
+	_, err = cosmos.GetRPCClient()
+	require.ErrorContains(t, err, "no rpc address configured")
+}
+
+func TestToSDKInt(t *testing.T) {
+	// 1000 tokens with 18 decimals, way above max int64
+	amount, ok := new(big.Int).SetString("1000000000000000000000", 10)
+	require.True(t, ok)
+
+	sdkAmount, err := toSDKInt(amount)
+	require.NoError(t, err)
+	require.Equal(t, amount.String(), sdkAmount.String())
+	require.Equal(t, amount, sdkAmount.BigInt())
+
+	_, err = toSDKInt(big.NewInt(-1))
+	require.Error(t, err)
+
+	_, err = toSDKInt(new(big.Int).Lsh(big.NewInt(1), 256))
+	require.Error(t, err)
+
+	_, err = toSDKInt(nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,126 @@
+package cosmos
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"fmt"
+
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ethSecp256k1PubKeyName is the proto message name used by EVM-compatible Cosmos chains (cosmos/evm) for eth_secp256k1 public keys.
+const ethSecp256k1PubKeyName = "cosmos.evm.crypto.v1.ethsecp256k1.PubKey"
+
+var (
+	_ cryptotypes.PrivKey = &ethSecp256k1PrivKey{}
+	_ cryptotypes.PubKey  = &ethSecp256k1PubKey{}
+)
+
+// ethSecp256k1PrivKey is a secp256k1 private key that signs keccak256 hashes and derives ethereum-style addresses,
+// as used by EVM-compatible Cosmos chains. It is created with newEthSecp256k1PrivKey, which validates the key.
+type ethSecp256k1PrivKey struct {
+	Key []byte
+	key *ecdsa.PrivateKey
+}
+
+type ethSecp256k1PubKey struct {
+	// Key is the compressed public key
+	Key     []byte
+	address ethcommon.Address
+}
+
+// newEthSecp256k1PrivKey returns an error if the bytes are not a valid secp256k1 private key,
+// so the key's methods never have to deal with an invalid one.
+func newEthSecp256k1PrivKey(keyBytes []byte) (*ethSecp256k1PrivKey, error) {
+	key, err := crypto.ToECDSA(keyBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid eth_secp256k1 private key")
+	}
+
+	return &ethSecp256k1PrivKey{Key: keyBytes, key: key}, nil
+}
+
+// Bytes implements cryptotypes.PrivKey.
+func (privKey *ethSecp256k1PrivKey) Bytes() []byte {
+	return privKey.Key
+}
+
+// Sign implements cryptotypes.PrivKey. The message is hashed with keccak256 before signing.
+func (privKey *ethSecp256k1PrivKey) Sign(msg []byte) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256(msg), privKey.key)
+}
+
+// PubKey implements cryptotypes.PrivKey.
+func (privKey *ethSecp256k1PrivKey) PubKey() cryptotypes.PubKey {
+	return &ethSecp256k1PubKey{
+		Key:     crypto.CompressPubkey(&privKey.key.PublicKey),
+		address: crypto.PubkeyToAddress(privKey.key.PublicKey),
+	}
+}
+
+// Equals implements cryptotypes.PrivKey.
+func (privKey *ethSecp256k1PrivKey) Equals(other cryptotypes.LedgerPrivKey) bool {
+	return privKey.Type() == other.Type() && bytes.Equal(privKey.Bytes(), other.Bytes())
+}
+
+// Type implements cryptotypes.PrivKey.
+func (privKey *ethSecp256k1PrivKey) Type() string {
+	return KeyTypeEthSecp256k1
+}
+
+func (privKey *ethSecp256k1PrivKey) Reset()        { *privKey = ethSecp256k1PrivKey{} }
+func (privKey *ethSecp256k1PrivKey) ProtoMessage() {}
+func (privKey *ethSecp256k1PrivKey) String() string {
+	return fmt.Sprintf("EthSecp256k1PrivKey{%X}", privKey.PubKey().Bytes())
+}
+
+// Address implements cryptotypes.PubKey. It is the ethereum address of the key.
+func (pubKey *ethSecp256k1PubKey) Address() cryptotypes.Address {
+	return pubKey.address.Bytes()
+}
+
+// Bytes implements cryptotypes.PubKey.
+func (pubKey *ethSecp256k1PubKey) Bytes() []byte {
+	return pubKey.Key
+}
+
+// VerifySignature implements cryptotypes.PubKey.
+func (pubKey *ethSecp256k1PubKey) VerifySignature(msg []byte, sig []byte) bool {
+	// Drop the recovery id if present
+	if len(sig) == crypto.SignatureLength {
+		sig = sig[:crypto.RecoveryIDOffset]
+	}
+
+	return crypto.VerifySignature(pubKey.Key, crypto.Keccak256(msg), sig)
+}
+
+// Equals implements cryptotypes.PubKey.
+func (pubKey *ethSecp256k1PubKey) Equals(other cryptotypes.PubKey) bool {
+	return pubKey.Type() == other.Type() && bytes.Equal(pubKey.Bytes(), other.Bytes())
+}
+
+// Type implements cryptotypes.PubKey.
+func (pubKey *ethSecp256k1PubKey) Type() string {
+	return KeyTypeEthSecp256k1
+}
+
+func (pubKey *ethSecp256k1PubKey) Reset()        { *pubKey = ethSecp256k1PubKey{} }
+func (pubKey *ethSecp256k1PubKey) ProtoMessage() {}
+func (pubKey *ethSecp256k1PubKey) String() string {
+	return fmt.Sprintf("EthSecp256k1PubKey{%X}", pubKey.Key)
+}
+
+// XXX_MessageName lets gogoproto resolve the type URL when the public key is packed into an Any (i.e. in the tx signer infos).
+func (pubKey *ethSecp256k1PubKey) XXX_MessageName() string {
+	return ethSecp256k1PubKeyName
+}
+
+// Marshal encodes the public key as the `bytes key = 1` proto message used on chain.
+func (pubKey *ethSecp256k1PubKey) Marshal() ([]byte, error) {
+	bz := protowire.AppendTag(nil, 1, protowire.BytesType)
+	return protowire.AppendBytes(bz, pubKey.Key), nil
+}
@@ -28,15 +28,7 @@ func ParsePackets(txHash string, events []abci.Event) ([]ibc.Packet, error) {
 
 		var packets []ibc.Packet
 		for _, packet := range v1Packets {
-			packets = append(packets, ibc.NewPacket(
-				txHash,
-				1,
-				packet.Sequence,
-				packet.SourcePort,
-				packet.DestinationPort,
-				packet.TimeoutTimestamp,
-				packet,
-			))
+			packets = append(packets, ibc.NewV1Packet(txHash, packet))
 		}
 		return packets, nil
 	case 2:
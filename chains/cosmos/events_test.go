@@ -0,0 +1,45 @@
+package cosmos
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePacketsV1(t *testing.T) {
+	events := []abci.Event{
+		{
+			Type: "message",
+			Attributes: []abci.EventAttribute{
+				{Key: "module", Value: "ibc_channel"},
+			},
+		},
+		{
+			Type: channeltypes.EventTypeSendPacket,
+			Attributes: []abci.EventAttribute{
+				{Key: channeltypes.AttributeKeySequence, Value: "3"},
+				{Key: channeltypes.AttributeKeySrcPort, Value: "transfer"},
+				{Key: channeltypes.AttributeKeySrcChannel, Value: "channel-0"},
+				{Key: channeltypes.AttributeKeyDstPort, Value: "transfer"},
+				{Key: channeltypes.AttributeKeyDstChannel, Value: "channel-5"},
+				{Key: channeltypes.AttributeKeyTimeoutTimestamp, Value: "1000"},
+			},
+		},
+	}
+
+	packets, err := ParsePackets("ABC", events)
+	require.NoError(t, err)
+	require.Len(t, packets, 1)
+
+	packet := packets[0]
+	require.Equal(t, uint(1), packet.IBCVersion)
+	require.Equal(t, uint64(3), packet.Sequence)
+	require.Equal(t, "transfer", packet.SourcePort)
+	require.Equal(t, "channel-0", packet.SourceChannel)
+	require.Equal(t, "transfer", packet.DestinationPort)
+	require.Equal(t, "channel-5", packet.DestinationChannel)
+	require.Equal(t, "channel-0", packet.SourceClient)
+	require.Equal(t, "channel-5", packet.DestinationClient)
+}
@@ -3,9 +3,9 @@ package cosmos
 import (
 	"context"
 
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
 	channeltypesv2 "github.com/cosmos/ibc-go/v10/modules/core/04-channel/v2/types"
 	"github.com/gjermundgaraba/libibc/ibc"
-	"github.com/gjermundgaraba/libibc/utils"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -21,9 +21,24 @@ func (c *Cosmos) GetPackets(ctx context.Context, txHash string) ([]ibc.Packet, e
 }
 
 func (c *Cosmos) IsPacketReceived(ctx context.Context, packet ibc.Packet) (bool, error) {
-	grpcConn, err := utils.GetGRPC(c.grpcAddr)
+	grpcConn, err := c.getGRPC()
 	if err != nil {
-		return false, errors.Wrap(err, "failed to get grpc connection")
+		return false, err
+	}
+
+	if packet.IBCVersion == 1 {
+		channelClient := channeltypes.NewQueryClient(grpcConn)
+		resp, err := channelClient.PacketReceipt(ctx, &channeltypes.QueryPacketReceiptRequest{
+			PortId:    packet.DestinationPort,
+			ChannelId: packet.DestinationChannel,
+			Sequence:  packet.Sequence,
+		})
+		if err != nil {
+			return false, errors.Wrap(err, "failed to query v1 packet receipt")
+		}
+		c.logger.Debug("Querying v1 packet receipt", zap.String("PortID", packet.DestinationPort), zap.String("ChannelID", packet.DestinationChannel), zap.Uint64("Sequence", packet.Sequence), zap.Any("Response", resp))
+
+		return resp.Received, nil
 	}
 
 	channelClient := channeltypesv2.NewQueryClient(grpcConn)
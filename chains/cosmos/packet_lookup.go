@@ -0,0 +1,141 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+	channeltypesv2 "github.com/cosmos/ibc-go/v10/modules/core/04-channel/v2/types"
+	"github.com/gjermundgaraba/libibc/ibc"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ErrPacketTxNotFound is returned when no transaction matches a packet lookup (yet).
+var ErrPacketTxNotFound = errors.New("packet tx not found")
+
+const txSearchPageSize = 100
+
+// PacketTx identifies the transaction (and block height) a packet event was emitted in.
+type PacketTx struct {
+	TxHash string
+	Height int64
+}
+
+// FindRecvPacketTx finds the recv_packet tx for the packet on this chain (i.e. this is the destination chain).
+func (c *Cosmos) FindRecvPacketTx(ctx context.Context, packet ibc.Packet) (PacketTx, error) {
+	return c.findPacketTx(ctx, channeltypesv2.EventTypeRecvPacket, packet)
+}
+
+// FindWriteAckTx finds the write_acknowledgement tx for the packet on this chain (i.e. this is the destination chain).
+func (c *Cosmos) FindWriteAckTx(ctx context.Context, packet ibc.Packet) (PacketTx, error) {
+	return c.findPacketTx(ctx, channeltypesv2.EventTypeWriteAck, packet)
+}
+
+// FindAckPacketTx finds the acknowledge_packet tx for the packet on this chain (i.e. this is the source chain).
+func (c *Cosmos) FindAckPacketTx(ctx context.Context, packet ibc.Packet) (PacketTx, error) {
+	return c.findPacketTx(ctx, channeltypesv2.EventTypeAcknowledgePacket, packet)
+}
+
+// FindTimeoutPacketTx finds the timeout_packet tx for the packet on this chain (i.e. this is the source chain).
+func (c *Cosmos) FindTimeoutPacketTx(ctx context.Context, packet ibc.Packet) (PacketTx, error) {
+	return c.findPacketTx(ctx, channeltypesv2.EventTypeTimeoutPacket, packet)
+}
+
+func (c *Cosmos) findPacketTx(ctx context.Context, eventType string, packet ibc.Packet) (PacketTx, error) {
+	query, err := packetEventQuery(eventType, packet)
+	if err != nil {
+		return PacketTx{}, err
+	}
+
+	txs, err := c.searchTxs(ctx, query)
+	if err != nil {
+		return PacketTx{}, err
+	}
+	if len(txs) == 0 {
+		return PacketTx{}, errors.Wrapf(ErrPacketTxNotFound, "no %s tx found for sequence %d (query: %s)", eventType, packet.Sequence, query)
+	}
+	if len(txs) > 1 {
+		c.logger.Debug("Found more than one tx for packet event, using the first one", zap.String("query", query), zap.Int("num_txs", len(txs)))
+	}
+
+	return txs[0], nil
+}
+
+// packetEventQuery builds a tx search query that matches the given event type for the packet.
+// The event attribute keys are the same for the event types we query, but differ between IBC versions.
+func packetEventQuery(eventType string, packet ibc.Packet) (string, error) {
+	conditions := []string{
+		fmt.Sprintf("%s.%s='%d'", eventType, channeltypesv2.AttributeKeySequence, packet.Sequence),
+	}
+
+	switch packet.IBCVersion {
+	case 1:
+		conditions = append(conditions,
+			fmt.Sprintf("%s.%s='%s'", eventType, channeltypes.AttributeKeySrcChannel, packet.SourceChannel),
+			fmt.Sprintf("%s.%s='%s'", eventType, channeltypes.AttributeKeyDstChannel, packet.DestinationChannel),
+		)
+	case 2:
+		conditions = append(conditions,
+			fmt.Sprintf("%s.%s='%s'", eventType, channeltypesv2.AttributeKeySrcClient, packet.SourceClient),
+			fmt.Sprintf("%s.%s='%s'", eventType, channeltypesv2.AttributeKeyDstClient, packet.DestinationClient),
+		)
+	default:
+		return "", errors.Errorf("unknown IBC version: %d", packet.IBCVersion)
+	}
+
+	return strings.Join(conditions, " AND "), nil
+}
+
+// searchTxs uses tx_search over RPC if an rpc address is configured, and falls back to GetTxsEvent over gRPC.
+func (c *Cosmos) searchTxs(ctx context.Context, query string) ([]PacketTx, error) {
+	if c.rpcEndpoints != nil {
+		rpcTxs, err := c.TxSearch(ctx, query, txSearchPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		txs := make([]PacketTx, len(rpcTxs))
+		for i, tx := range rpcTxs {
+			txs[i] = PacketTx{
+				TxHash: tx.Hash.String(),
+				Height: tx.Height,
+			}
+		}
+		return txs, nil
+	}
+
+	grpcConn, err := c.getGRPC()
+	if err != nil {
+		return nil, err
+	}
+
+	txClient := txtypes.NewServiceClient(grpcConn)
+	var txs []PacketTx
+	for page := uint64(1); ; page++ {
+		resp, err := txClient.GetTxsEvent(ctx, &txtypes.GetTxsEventRequest{
+			Query:   query,
+			Page:    page,
+			Limit:   txSearchPageSize,
+			OrderBy: txtypes.OrderBy_ORDER_BY_ASC,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to query txs with query %s (page %d)", query, page)
+		}
+
+		for _, txResp := range resp.TxResponses {
+			txs = append(txs, PacketTx{
+				TxHash: txResp.TxHash,
+				Height: txResp.Height,
+			})
+		}
+
+		if len(resp.TxResponses) == 0 || uint64(len(txs)) >= resp.Total {
+			break
+		}
+	}
+
+	return txs, nil
+}
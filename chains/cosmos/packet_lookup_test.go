@@ -0,0 +1,28 @@
+package cosmos
+
+import (
+	"testing"
+
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+	channeltypesv2 "github.com/cosmos/ibc-go/v10/modules/core/04-channel/v2/types"
+	"github.com/gjermundgaraba/libibc/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketEventQuery(t *testing.T) {
+	v2Packet := ibc.NewPacket("", 2, 42, "08-wasm-0", "client-0", 0, channeltypesv2.Packet{})
+	query, err := packetEventQuery(channeltypesv2.EventTypeRecvPacket, v2Packet)
+	require.NoError(t, err)
+	require.Equal(t, "recv_packet.packet_sequence='42' AND recv_packet.packet_source_client='08-wasm-0' AND recv_packet.packet_dest_client='client-0'", query)
+
+	v1Packet := ibc.NewV1Packet("", channeltypes.Packet{
+		Sequence:           7,
+		SourcePort:         "transfer",
+		SourceChannel:      "channel-1",
+		DestinationPort:    "transfer",
+		DestinationChannel: "channel-2",
+	})
+	query, err = packetEventQuery(channeltypesv2.EventTypeAcknowledgePacket, v1Packet)
+	require.NoError(t, err)
+	require.Equal(t, "acknowledge_packet.packet_sequence='7' AND acknowledge_packet.packet_src_channel='channel-1' AND acknowledge_packet.packet_dst_channel='channel-2'", query)
+}
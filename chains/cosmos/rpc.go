@@ -0,0 +1,225 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const rpcWebsocketEndpoint = "/websocket"
+
+// GetRPCClient returns the CometBFT RPC client for the chain's configured rpc addresses.
+// The client is created on first use and shared by all calls, and requests fail over between the rpc addresses.
+// It is not meant to be started, since SubscribeEvents uses a websocket client of its own for each subscription.
+func (c *Cosmos) GetRPCClient() (*rpchttp.HTTP, error) {
+	if c.rpcEndpoints == nil {
+		return nil, errors.Errorf("no rpc address configured for chain %s", c.ChainID)
+	}
+
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	if c.rpcClient != nil {
+		return c.rpcClient, nil
+	}
+
+	httpClient := &http.Client{Transport: c.rpcEndpoints.FailoverTransport(nil)}
+	client, err := rpchttp.NewWithClient(c.rpcEndpoints.Primary(), rpcWebsocketEndpoint, httpClient)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create rpc client for %s", c.rpcEndpoints.Primary())
+	}
+	c.rpcClient = client
+
+	return c.rpcClient, nil
+}
+
+// GetStatus returns the node status, which includes the latest block height and time.
+func (c *Cosmos) GetStatus(ctx context.Context) (*coretypes.ResultStatus, error) {
+	rpcClient, err := c.GetRPCClient()
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := rpcClient.Status(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query status")
+	}
+
+	return status, nil
+}
+
+// GetLatestHeight returns the latest block height and the time of that block.
+func (c *Cosmos) GetLatestHeight(ctx context.Context) (int64, time.Time, error) {
+	status, err := c.GetStatus(ctx)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return status.SyncInfo.LatestBlockHeight, status.SyncInfo.LatestBlockTime, nil
+}
+
+// GetBlock returns the block at the given height. A height of 0 returns the latest block.
+func (c *Cosmos) GetBlock(ctx context.Context, height int64) (*coretypes.ResultBlock, error) {
+	rpcClient, err := c.GetRPCClient()
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := rpcClient.Block(ctx, heightOrLatest(height))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query block at height %d", height)
+	}
+
+	return block, nil
+}
+
+// GetHeader returns the block header at the given height. A height of 0 returns the latest header.
+func (c *Cosmos) GetHeader(ctx context.Context, height int64) (*coretypes.ResultHeader, error) {
+	rpcClient, err := c.GetRPCClient()
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := rpcClient.Header(ctx, heightOrLatest(height))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query header at height %d", height)
+	}
+
+	return header, nil
+}
+
+// GetBlockResults returns the results (tx results and finalize block events) for the block at the given height.
+// A height of 0 returns the results for the latest block.
+func (c *Cosmos) GetBlockResults(ctx context.Context, height int64) (*coretypes.ResultBlockResults, error) {
+	rpcClient, err := c.GetRPCClient()
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := rpcClient.BlockResults(ctx, heightOrLatest(height))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query block results at height %d", height)
+	}
+
+	return results, nil
+}
+
+// GetRPCTx looks up a single transaction by its hex encoded hash through the RPC.
+func (c *Cosmos) GetRPCTx(ctx context.Context, txHash string) (*coretypes.ResultTx, error) {
+	hash, err := hex.DecodeString(txHash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode tx hash %s", txHash)
+	}
+
+	rpcClient, err := c.GetRPCClient()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := rpcClient.Tx(ctx, hash, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query tx %s", txHash)
+	}
+
+	return tx, nil
+}
+
+// TxSearch runs a tx_search query (e.g. "send_packet.packet_sequence='1'") and returns every matching tx,
+// paging through the results perPage at a time.
+func (c *Cosmos) TxSearch(ctx context.Context, query string, perPage int) ([]*coretypes.ResultTx, error) {
+	rpcClient, err := c.GetRPCClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var txs []*coretypes.ResultTx
+	for page := 1; ; page++ {
+		resp, err := rpcClient.TxSearch(ctx, query, false, &page, &perPage, "asc")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to search txs with query %s (page %d)", query, page)
+		}
+
+		txs = append(txs, resp.Txs...)
+		if len(resp.Txs) == 0 || len(txs) >= resp.TotalCount {
+			break
+		}
+	}
+
+	c.logger.Debug("tx search", zap.String("query", query), zap.Int("num_txs", len(txs)))
+
+	return txs, nil
+}
+
+// SubscribeEvents subscribes to events matching the query (e.g. "tm.event='NewBlock'") over the RPC websocket
+// of the first rpc address. Every subscription has a websocket client of its own, since a stopped client can't be restarted.
+// The returned function unsubscribes and stops that client, and must be called when done (or the chain closed).
+func (c *Cosmos) SubscribeEvents(ctx context.Context, subscriber string, query string) (<-chan coretypes.ResultEvent, func(), error) {
+	if c.rpcEndpoints == nil {
+		return nil, nil, errors.Errorf("no rpc address configured for chain %s", c.ChainID)
+	}
+
+	rpcClient, err := rpchttp.New(c.rpcEndpoints.Primary(), rpcWebsocketEndpoint)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to create rpc websocket client for %s", c.rpcEndpoints.Primary())
+	}
+
+	if err := rpcClient.Start(); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to start rpc websocket client")
+	}
+
+	eventCh, err := rpcClient.Subscribe(ctx, subscriber, query)
+	if err != nil {
+		_ = rpcClient.Stop()
+		return nil, nil, errors.Wrapf(err, "failed to subscribe to %s", query)
+	}
+
+	c.connMu.Lock()
+	if c.subscriptionClients == nil {
+		c.subscriptionClients = make(map[*rpchttp.HTTP]struct{})
+	}
+	c.subscriptionClients[rpcClient] = struct{}{}
+	c.connMu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			c.connMu.Lock()
+			_, running := c.subscriptionClients[rpcClient]
+			delete(c.subscriptionClients, rpcClient)
+			c.connMu.Unlock()
+
+			// Close already stopped the client
+			if !running {
+				return
+			}
+
+			if err := rpcClient.Unsubscribe(context.Background(), subscriber, query); err != nil {
+				c.logger.Debug("failed to unsubscribe", zap.String("query", query), zap.Error(err))
+			}
+			c.stopSubscriptionClient(rpcClient)
+		})
+	}
+
+	return eventCh, unsubscribe, nil
+}
+
+func (c *Cosmos) stopSubscriptionClient(rpcClient *rpchttp.HTTP) {
+	if err := rpcClient.Stop(); err != nil {
+		c.logger.Debug("failed to stop rpc websocket client", zap.Error(err))
+	}
+}
+
+func heightOrLatest(height int64) *int64 {
+	if height <= 0 {
+		return nil
+	}
+
+	return &height
+}
@@ -0,0 +1,75 @@
+package cosmos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newWebsocketServer serves a CometBFT RPC websocket that accepts any subscription.
+func newWebsocketServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	funcs := map[string]*rpcserver.RPCFunc{
+		"subscribe": rpcserver.NewWSRPCFunc(func(*rpctypes.Context, string) (*coretypes.ResultSubscribe, error) {
+			return &coretypes.ResultSubscribe{}, nil
+		}, "query"),
+		"unsubscribe": rpcserver.NewWSRPCFunc(func(*rpctypes.Context, string) (*coretypes.ResultUnsubscribe, error) {
+			return &coretypes.ResultUnsubscribe{}, nil
+		}, "query"),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(rpcWebsocketEndpoint, rpcserver.NewWebsocketManager(funcs).WebsocketHandler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	ctx := context.Background()
+	server := newWebsocketServer(t)
+
+	cosmos, err := NewCosmos(zap.NewNop(), "test-chain-id", "", server.URL, "", "")
+	require.NoError(t, err)
+
+	const query = "tm.event='NewBlock'"
+
+	// Concurrent subscriptions each get a client of their own
+	_, unsubscribeFirst, err := cosmos.SubscribeEvents(ctx, "first", query)
+	require.NoError(t, err)
+	_, unsubscribeSecond, err := cosmos.SubscribeEvents(ctx, "second", query)
+	require.NoError(t, err)
+	require.Len(t, cosmos.subscriptionClients, 2)
+
+	// Unsubscribing stops only that subscription's client, so later subscriptions still work
+	unsubscribeFirst()
+	unsubscribeFirst()
+	require.Len(t, cosmos.subscriptionClients, 1)
+	_, unsubscribeThird, err := cosmos.SubscribeEvents(ctx, "third", query)
+	require.NoError(t, err)
+
+	running := make([]*rpchttp.HTTP, 0, len(cosmos.subscriptionClients))
+	for rpcClient := range cosmos.subscriptionClients {
+		require.True(t, rpcClient.IsRunning())
+		running = append(running, rpcClient)
+	}
+
+	// Close stops the clients that are still running, after which unsubscribing does nothing
+	require.NoError(t, cosmos.Close())
+	for _, rpcClient := range running {
+		require.False(t, rpcClient.IsRunning())
+	}
+	unsubscribeSecond()
+	unsubscribeThird()
+	require.Empty(t, cosmos.subscriptionClients)
+}
@@ -13,7 +13,6 @@ import (
 	accounttypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/gjermundgaraba/libibc/chains/network"
-	"github.com/gjermundgaraba/libibc/utils"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -24,10 +23,13 @@ func (c *Cosmos) Send(ctx context.Context, senderWallet network.Wallet, amount *
 		return "", errors.Errorf("invalid wallet type: %T", senderWallet)
 	}
 	fromAddress := senderWallet.Address()
+	if err := c.ValidateAddress(toAddress); err != nil {
+		return "", err
+	}
 
-	grpcConn, err := utils.GetGRPC(c.grpcAddr)
+	grpcConn, err := c.getGRPC()
 	if err != nil {
-		return "", errors.Wrap(err, "failed to get gRPC connection")
+		return "", err
 	}
 
 	accountClient := accounttypes.NewQueryClient(grpcConn)
@@ -36,12 +38,16 @@ func (c *Cosmos) Send(ctx context.Context, senderWallet network.Wallet, amount *
 		return "", errors.Wrap(err, "failed to get account info")
 	}
 
-	amountCoin := sdk.NewInt64Coin(denom, amount.Int64())
-	sendMsg := banktypes.NewMsgSend(
-		sdk.MustAccAddressFromBech32(fromAddress),
-		sdk.MustAccAddressFromBech32(toAddress),
-		sdk.NewCoins(amountCoin),
-	)
+	sdkAmount, err := toSDKInt(amount)
+	if err != nil {
+		return "", err
+	}
+	amountCoin := sdk.NewCoin(denom, sdkAmount)
+	sendMsg := &banktypes.MsgSend{
+		FromAddress: fromAddress,
+		ToAddress:   toAddress,
+		Amount:      sdk.NewCoins(amountCoin),
+	}
 
 	txCfg := authtx.NewTxConfig(c.codec, authtx.DefaultSignModes)
 	txBuilder := txCfg.NewTxBuilder()
@@ -5,9 +5,10 @@ import (
 	"math/big"
 	"time"
 
-	sdkmath "cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	transfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v10/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
 	channeltypesv2 "github.com/cosmos/ibc-go/v10/modules/core/04-channel/v2/types"
 	"github.com/gjermundgaraba/libibc/chains/network"
 	"github.com/gjermundgaraba/libibc/ibc"
@@ -25,24 +26,113 @@ func (c *Cosmos) SendTransfer(
 	to string,
 	memo string,
 ) (ibc.Packet, error) {
+	packets, err := c.SendTransfers(ctx, clientID, wallet, []network.Transfer{
+		{
+			Amount: amount,
+			Denom:  denom,
+			To:     to,
+			Memo:   memo,
+		},
+	})
+	if err != nil {
+		return ibc.Packet{}, err
+	}
+	if len(packets) != 1 {
+		return ibc.Packet{}, errors.Errorf("failed to get packet for transfer (expected 1, got %d)", len(packets))
+	}
+
+	return packets[0], nil
+}
+
+// SendTransfers implements network.BatchTransferer.
+// All the transfers are packed into a single tx, and the packets are returned in the same order as the transfers.
+func (c *Cosmos) SendTransfers(ctx context.Context, clientID string, wallet network.Wallet, transfers []network.Transfer) ([]ibc.Packet, error) {
 	cosmosWallet, ok := wallet.(*Wallet)
 	if !ok {
-		return ibc.Packet{}, errors.Errorf("invalid wallet type: %T", wallet)
+		return nil, errors.Errorf("invalid wallet type: %T", wallet)
+	}
+	if len(transfers) == 0 {
+		return nil, errors.New("no transfers to send")
+	}
+
+	msgs := make([]sdk.Msg, len(transfers))
+	for i, transfer := range transfers {
+		transferCoin, err := newTransferCoin(transfer.Amount, transfer.Denom)
+		if err != nil {
+			return nil, err
+		}
+
+		if channeltypes.IsValidChannelID(clientID) {
+			msgs[i] = newMsgTransfer(clientID, wallet.Address(), transferCoin, transfer.To, transfer.Memo)
+		} else {
+			msgSendPacket, err := newMsgSendPacket(clientID, wallet.Address(), transferCoin, transfer.To, transfer.Memo)
+			if err != nil {
+				return nil, err
+			}
+			msgs[i] = msgSendPacket
+		}
+	}
+
+	resp, err := c.submitTx(ctx, cosmosWallet, uint64(len(msgs))*200_000, msgs...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to submit tx")
+	}
+
+	packets, err := c.GetPackets(ctx, resp.TxResponse.TxHash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get packets for transfer with tx hash: %s", resp.TxResponse.TxHash)
+	}
+	if len(packets) != len(transfers) {
+		return nil, errors.Errorf("failed to get packets for transfer (expected %d, got %d)", len(transfers), len(packets))
+	}
+
+	for _, transfer := range transfers {
+		c.logger.Info("Sent transfer", zap.String("tx_hash", resp.TxResponse.TxHash), zap.String("from", wallet.Address()), zap.String("to", transfer.To), zap.String("amount", transfer.Amount.String()), zap.String("denom", transfer.Denom))
+	}
+
+	return packets, nil
+}
+
+func newTransferCoin(amount *big.Int, denom string) (sdk.Coin, error) {
+	sdkAmount, err := toSDKInt(amount)
+	if err != nil {
+		return sdk.Coin{}, errors.Wrap(err, "invalid transfer amount")
 	}
 
+	return sdk.NewCoin(denom, sdkAmount), nil
+}
+
+// newMsgTransfer creates a classic ICS20 (IBC v1) transfer over the transfer port and the given channel.
+func newMsgTransfer(channelID string, sender string, transferCoin sdk.Coin, to string, memo string) *transfertypes.MsgTransfer {
+	// IBC v1 timeouts are in nanoseconds
+	timeout := uint64(time.Now().Add(6 * time.Hour).UnixNano())
+
+	return transfertypes.NewMsgTransfer(
+		transfertypes.PortID,
+		channelID,
+		transferCoin,
+		sender,
+		to,
+		clienttypes.ZeroHeight(),
+		timeout,
+		memo,
+	)
+}
+
+// newMsgSendPacket creates an IBC v2 (Eureka) packet with an ABI encoded ICS20 payload over the given client.
+func newMsgSendPacket(clientID string, sender string, transferCoin sdk.Coin, to string, memo string) (*channeltypesv2.MsgSendPacket, error) {
 	timeout := uint64(time.Now().Add(6 * time.Hour).Unix())
-	transferCoin := sdk.NewCoin(denom, sdkmath.NewIntFromBigInt(amount))
 
 	transferPayload := transfertypes.FungibleTokenPacketData{
 		Denom:    transferCoin.Denom,
 		Amount:   transferCoin.Amount.String(),
-		Sender:   wallet.Address(),
+		Sender:   sender,
 		Receiver: to,
 		Memo:     memo,
 	}
 	encodedPayload, err := transfertypes.EncodeABIFungibleTokenPacketData(&transferPayload)
 	if err != nil {
-		return ibc.Packet{}, errors.Wrap(err, "failed to encode transfer payload")
+		return nil, errors.Wrap(err, "failed to encode transfer payload")
 	}
 
 	payload := channeltypesv2.Payload{
@@ -58,23 +148,8 @@ func (c *Cosmos) SendTransfer(
 		Payloads: []channeltypesv2.Payload{
 			payload,
 		},
-		Signer: wallet.Address(),
+		Signer: sender,
 	}
 
-	resp, err := c.submitTx(ctx, cosmosWallet, 200_000, &msgSendPacket)
-	if err != nil {
-		return ibc.Packet{}, errors.Wrap(err, "failed to submit tx")
-	}
-
-	packets, err := c.GetPackets(ctx, resp.TxResponse.TxHash)
-	if err != nil {
-		return ibc.Packet{}, errors.Wrapf(err, "failed to get packets for transfer with tx hash: %s", resp.TxResponse.TxHash)
-	}
-	if len(packets) != 1 {
-		return ibc.Packet{}, errors.Errorf("failed to get packet for transfer (expected 1, got %d)", len(packets))
-	}
-
-	c.logger.Info("Sent transfer", zap.String("tx_hash", resp.TxResponse.TxHash), zap.String("from", wallet.Address()), zap.String("to", to), zap.Uint64("amount", amount.Uint64()), zap.String("denom", denom))
-
-	return packets[0], nil
+	return &msgSendPacket, nil
 }
@@ -16,7 +16,6 @@ import (
 	accounttypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	"github.com/cosmos/gogoproto/proto"
 	"github.com/gjermundgaraba/libibc/chains/network"
-	"github.com/gjermundgaraba/libibc/utils"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -57,9 +56,9 @@ func (c *Cosmos) SubmitRelayTx(ctx context.Context, txBz []byte, wallet network.
 }
 
 func (c *Cosmos) submitTx(ctx context.Context, wallet *Wallet, gas uint64, msgs ...sdk.Msg) (*txtypes.BroadcastTxResponse, error) {
-	grpcConn, err := utils.GetGRPC(c.grpcAddr)
+	grpcConn, err := c.getGRPC()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get grpc connection")
+		return nil, err
 	}
 
 	// Get account for sequence and account number
@@ -7,6 +7,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/gjermundgaraba/libibc/chains/network"
 	"github.com/pkg/errors"
 )
@@ -14,9 +15,10 @@ import (
 var _ network.Wallet = &Wallet{}
 
 type Wallet struct {
-	id         string
-	address    cryptotypes.Address
-	privateKey *secp256k1.PrivKey
+	id           string
+	address      cryptotypes.Address
+	privateKey   cryptotypes.PrivKey
+	bech32Prefix string
 }
 
 func (c *Cosmos) AddWallet(walletID string, privateKeyHex string) error {
@@ -24,17 +26,35 @@ func (c *Cosmos) AddWallet(walletID string, privateKeyHex string) error {
 	if err != nil {
 		return fmt.Errorf("invalid key string: %w", err)
 	}
-	privKey := &secp256k1.PrivKey{Key: keyBytes}
 
-	c.Wallets[walletID] = Wallet{
-		id:         walletID,
-		address:    privKey.PubKey().Address(),
-		privateKey: privKey,
+	var privKey cryptotypes.PrivKey
+	switch c.keyType {
+	case KeyTypeEthSecp256k1:
+		privKey, err = newEthSecp256k1PrivKey(keyBytes)
+		if err != nil {
+			return err
+		}
+	default:
+		if len(keyBytes) != secp256k1.PrivKeySize {
+			return errors.Errorf("invalid secp256k1 private key length %d, must be %d bytes", len(keyBytes), secp256k1.PrivKeySize)
+		}
+		privKey = &secp256k1.PrivKey{Key: keyBytes}
 	}
 
+	c.Wallets[walletID] = c.newWallet(walletID, privKey)
+
 	return nil
 }
 
+func (c *Cosmos) newWallet(walletID string, privKey cryptotypes.PrivKey) Wallet {
+	return Wallet{
+		id:           walletID,
+		address:      privKey.PubKey().Address(),
+		privateKey:   privKey,
+		bech32Prefix: c.bech32Prefix,
+	}
+}
+
 func (c *Cosmos) GetWallet(walletID string) (network.Wallet, error) {
 	wallet, ok := c.Wallets[walletID]
 	if !ok {
@@ -46,14 +66,20 @@ func (c *Cosmos) GetWallet(walletID string) (network.Wallet, error) {
 // GenerateWallet implements network.Chain.
 func (c *Cosmos) GenerateWallet(walletID string) (network.Wallet, error) {
 	// Generate a new private key
-	privKey := secp256k1.GenPrivKey()
+	var privKey cryptotypes.PrivKey
+	switch c.keyType {
+	case KeyTypeEthSecp256k1:
+		ecdsaKey, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate eth_secp256k1 private key")
+		}
+		privKey = &ethSecp256k1PrivKey{Key: crypto.FromECDSA(ecdsaKey), key: ecdsaKey}
+	default:
+		privKey = secp256k1.GenPrivKey()
+	}
 
 	// Create wallet
-	wallet := Wallet{
-		id:         walletID,
-		address:    privKey.PubKey().Address(),
-		privateKey: privKey,
-	}
+	wallet := c.newWallet(walletID, privKey)
 
 	// Store wallet
 	c.Wallets[walletID] = wallet
@@ -72,7 +98,7 @@ func (c *Cosmos) GetWallets() []network.Wallet {
 
 // GetAddress implements network.Wallet.
 func (w *Wallet) Address() string {
-	return sdk.AccAddress(w.address).String()
+	return sdk.MustBech32ifyAddressBytes(w.bech32Prefix, w.address)
 }
 
 // GetID implements network.Wallet.
@@ -82,5 +108,5 @@ func (w *Wallet) ID() string {
 
 // GetPrivateKeyHex implements network.Wallet.
 func (w *Wallet) PrivateKeyHex() string {
-	return hex.EncodeToString(w.privateKey.Key)
+	return hex.EncodeToString(w.privateKey.Bytes())
 }
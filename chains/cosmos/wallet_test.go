@@ -1,8 +1,18 @@
 package cosmos
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	xauthsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -13,7 +23,7 @@ func TestWalletGetAddress(t *testing.T) {
 	const expectedAddress = "cosmos1maysgktd0ugpnrdkkyls8qyap83gk3wt7hxdp5"
 
 	testLogger, _ := zap.NewDevelopment()
-	cosmos, err := NewCosmos(testLogger, "test-chain-id", "")
+	cosmos, err := NewCosmos(testLogger, "test-chain-id", "", "", "", "")
 	require.NoError(t, err)
 
 	// Act
@@ -28,3 +38,94 @@ func TestWalletGetAddress(t *testing.T) {
 	require.Equal(t, expectedAddress, address)
 }
 
+func TestWalletAddressWithPrefix(t *testing.T) {
+	const privateKeyHex = "8cb79e7fe3de7bfe364e0c5f3a89de39a1472bb67a33ee853d3215a19c476c27"
+	const expectedAddress = "osmo1maysgktd0ugpnrdkkyls8qyap83gk3wtkv4ahx"
+
+	testLogger, _ := zap.NewDevelopment()
+	cosmos, err := NewCosmos(testLogger, "test-chain-id", "", "", "osmo", "")
+	require.NoError(t, err)
+
+	err = cosmos.AddWallet("test-wallet", privateKeyHex)
+	require.NoError(t, err)
+
+	wallet := cosmos.Wallets["test-wallet"]
+	require.Equal(t, expectedAddress, wallet.Address())
+	require.NoError(t, cosmos.ValidateAddress(wallet.Address()))
+	require.Error(t, cosmos.ValidateAddress("cosmos1maysgktd0ugpnrdkkyls8qyap83gk3wt7hxdp5"))
+}
+
+func TestEthSecp256k1Wallet(t *testing.T) {
+	// The bech32 address is the ethereum address of the key with the chain prefix
+	const privateKeyHex = "8cb79e7fe3de7bfe364e0c5f3a89de39a1472bb67a33ee853d3215a19c476c27"
+
+	testLogger, _ := zap.NewDevelopment()
+	cosmos, err := NewCosmos(testLogger, "test-chain-id", "", "", "evm", KeyTypeEthSecp256k1)
+	require.NoError(t, err)
+
+	err = cosmos.AddWallet("test-wallet", privateKeyHex)
+	require.NoError(t, err)
+
+	wallet := cosmos.Wallets["test-wallet"]
+	ecdsaKey, err := crypto.HexToECDSA(privateKeyHex)
+	require.NoError(t, err)
+	expectedAddress := sdk.MustBech32ifyAddressBytes("evm", crypto.PubkeyToAddress(ecdsaKey.PublicKey).Bytes())
+	require.Equal(t, expectedAddress, wallet.Address())
+	require.Equal(t, privateKeyHex, wallet.PrivateKeyHex())
+
+	// Sign a tx and make sure the signature verifies and the public key is packed with the expected type url
+	txCfg := authtx.NewTxConfig(cosmos.codec, authtx.DefaultSignModes)
+	txBuilder := txCfg.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(&banktypes.MsgSend{FromAddress: wallet.Address(), ToAddress: wallet.Address()}))
+
+	signMode := signing.SignMode(txCfg.SignModeHandler().DefaultMode())
+	require.NoError(t, txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey:   wallet.privateKey.PubKey(),
+		Data:     &signing.SingleSignatureData{SignMode: signMode},
+		Sequence: 1,
+	}))
+	sig, err := tx.SignWithPrivKey(context.Background(), signMode, xauthsigning.SignerData{
+		Address:       wallet.Address(),
+		ChainID:       "test-chain-id",
+		AccountNumber: 1,
+		Sequence:      1,
+	}, txBuilder, wallet.privateKey, txCfg, 1)
+	require.NoError(t, err)
+	require.NoError(t, txBuilder.SetSignatures(sig))
+
+	txBz, err := txCfg.TxEncoder()(txBuilder.GetTx())
+	require.NoError(t, err)
+
+	var rawTx txtypes.TxRaw
+	require.NoError(t, rawTx.Unmarshal(txBz))
+	var authInfo txtypes.AuthInfo
+	require.NoError(t, authInfo.Unmarshal(rawTx.AuthInfoBytes))
+	require.Equal(t, "/"+ethSecp256k1PubKeyName, authInfo.SignerInfos[0].PublicKey.TypeUrl)
+
+	signBytes, err := (&txtypes.SignDoc{
+		BodyBytes:     rawTx.BodyBytes,
+		AuthInfoBytes: rawTx.AuthInfoBytes,
+		ChainId:       "test-chain-id",
+		AccountNumber: 1,
+	}).Marshal()
+	require.NoError(t, err)
+	require.True(t, wallet.privateKey.PubKey().VerifySignature(signBytes, rawTx.Signatures[0]))
+}
+
+func TestAddWalletInvalidKey(t *testing.T) {
+	testLogger, _ := zap.NewDevelopment()
+
+	for _, keyType := range []string{"", KeyTypeEthSecp256k1} {
+		cosmos, err := NewCosmos(testLogger, "test-chain-id", "", "", "", keyType)
+		require.NoError(t, err)
+
+		require.Error(t, cosmos.AddWallet("short-key", "0102"))
+		require.Error(t, cosmos.AddWallet("not-hex", "not hex"))
+		require.Empty(t, cosmos.Wallets)
+	}
+
+	// Zero is not a valid secp256k1 private key
+	cosmos, err := NewCosmos(testLogger, "test-chain-id", "", "", "", KeyTypeEthSecp256k1)
+	require.NoError(t, err)
+	require.Error(t, cosmos.AddWallet("zero-key", strings.Repeat("00", 32)))
+}
@@ -0,0 +1,157 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"time"
+
+	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/ics20transfer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/gjermundgaraba/libibc/chains/ethereum/erc20"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ApprovalStrategy decides how the ICS20 contract is allowed to move tokens on behalf of the sender.
+type ApprovalStrategy string
+
+const (
+	// ApprovalStrategyApprove approves exactly the amount being transferred whenever the allowance is too low.
+	ApprovalStrategyApprove ApprovalStrategy = "approve"
+	// ApprovalStrategyInfinite approves the max amount once, so later transfers of the same token don't need an approve tx.
+	ApprovalStrategyInfinite ApprovalStrategy = "infinite"
+	// ApprovalStrategyPermit2 signs a Permit2 transfer and sends it with permitSendTransfer, so transfers don't need an approve tx.
+	// The token still needs an allowance for the Permit2 contract, which is set once with an infinite approval.
+	// If the ICS20 contract has no Permit2 contract configured, the approve strategy is used.
+	ApprovalStrategyPermit2 ApprovalStrategy = "permit2"
+)
+
+// permit2Deadline is how long a signed Permit2 transfer is valid for
+const permit2Deadline = 30 * time.Minute
+
+// ParseApprovalStrategy parses an approval strategy, where an empty string means the default (approve).
+func ParseApprovalStrategy(strategy string) (ApprovalStrategy, error) {
+	switch ApprovalStrategy(strategy) {
+	case "":
+		return ApprovalStrategyApprove, nil
+	case ApprovalStrategyApprove, ApprovalStrategyInfinite, ApprovalStrategyPermit2:
+		return ApprovalStrategy(strategy), nil
+	default:
+		return "", errors.Errorf("unknown approval strategy: %s (must be one of %s, %s or %s)", strategy, ApprovalStrategyApprove, ApprovalStrategyInfinite, ApprovalStrategyPermit2)
+	}
+}
+
+// ensureAllowance approves the spender to transfer the amount of the token from the wallet, if the current allowance is too low.
+// With infinite set, the max amount is approved instead of just the amount needed.
+func (e *Ethereum) ensureAllowance(ctx context.Context, wallet *Wallet, erc20Contract *erc20.Contract, spender ethcommon.Address, amount *big.Int, infinite bool) error {
+	currentApproval, err := erc20Contract.Allowance(&bind.CallOpts{Context: ctx}, wallet.address, spender)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current approval")
+	}
+
+	if currentApproval.Cmp(amount) >= 0 {
+		return nil
+	}
+
+	approveAmount := amount
+	if infinite {
+		approveAmount = abi.MaxUint256
+	}
+
+	// Transact waits for the receipt, so the allowance is in place once it returns
+	receipt, err := e.Transact(ctx, wallet, func(_ Client, txOpts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+		return erc20Contract.Approve(txOpts, spender, approveAmount)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to approve %s", spender.String())
+	}
+
+	e.logger.Info("Approved transfer", zap.String("tx_hash", receipt.TxHash.String()), zap.String("spender", spender.String()), zap.String("amount", approveAmount.String()))
+
+	return nil
+}
+
+// newPermit2Transfer creates and signs a Permit2 transfer that lets the ICS20 contract transfer the amount of the token from the wallet.
+func (e *Ethereum) newPermit2Transfer(wallet *Wallet, permit2Address ethcommon.Address, token ethcommon.Address, amount *big.Int) (ics20transfer.ISignatureTransferPermitTransferFrom, []byte, error) {
+	// Permit2 uses unordered nonces, so any unused nonce works
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 248))
+	if err != nil {
+		return ics20transfer.ISignatureTransferPermitTransferFrom{}, nil, errors.Wrap(err, "failed to generate permit2 nonce")
+	}
+
+	permit := ics20transfer.ISignatureTransferPermitTransferFrom{
+		Permitted: ics20transfer.ISignatureTransferTokenPermissions{
+			Token:  token,
+			Amount: amount,
+		},
+		Nonce:    nonce,
+		Deadline: big.NewInt(time.Now().Add(permit2Deadline).Unix()),
+	}
+
+	signature, err := signPermit2Transfer(wallet, e.actualChainID, permit2Address, e.ics20Address, permit)
+	if err != nil {
+		return ics20transfer.ISignatureTransferPermitTransferFrom{}, nil, err
+	}
+
+	return permit, signature, nil
+}
+
+// signPermit2Transfer signs the EIP-712 PermitTransferFrom message used by the Permit2 SignatureTransfer contract.
+func signPermit2Transfer(wallet *Wallet, chainID *big.Int, permit2Address ethcommon.Address, spender ethcommon.Address, permit ics20transfer.ISignatureTransferPermitTransferFrom) ([]byte, error) {
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"PermitTransferFrom": {
+				{Name: "permitted", Type: "TokenPermissions"},
+				{Name: "spender", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint256"},
+			},
+			"TokenPermissions": {
+				{Name: "token", Type: "address"},
+				{Name: "amount", Type: "uint256"},
+			},
+		},
+		PrimaryType: "PermitTransferFrom",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "Permit2",
+			ChainId:           (*math.HexOrDecimal256)(chainID),
+			VerifyingContract: permit2Address.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"permitted": map[string]interface{}{
+				"token":  permit.Permitted.Token.Hex(),
+				"amount": permit.Permitted.Amount.String(),
+			},
+			"spender":  spender.Hex(),
+			"nonce":    permit.Nonce.String(),
+			"deadline": permit.Deadline.String(),
+		},
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hash permit2 typed data")
+	}
+
+	signature, err := crypto.Sign(hash, wallet.privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign permit2 transfer")
+	}
+
+	// Solidity's ecrecover expects v to be 27 or 28
+	signature[crypto.RecoveryIDOffset] += 27
+
+	return signature, nil
+}
@@ -0,0 +1,66 @@
+package ethereum
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/ics20transfer"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseApprovalStrategy(t *testing.T) {
+	strategy, err := ParseApprovalStrategy("")
+	require.NoError(t, err)
+	require.Equal(t, ApprovalStrategyApprove, strategy)
+
+	strategy, err = ParseApprovalStrategy("permit2")
+	require.NoError(t, err)
+	require.Equal(t, ApprovalStrategyPermit2, strategy)
+
+	_, err = ParseApprovalStrategy("eip2612")
+	require.Error(t, err)
+}
+
+func TestSignPermit2Transfer(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	wallet := &Wallet{id: "test", address: crypto.PubkeyToAddress(key.PublicKey), privateKey: key}
+
+	chainID := big.NewInt(11155111)
+	permit2Address := ethcommon.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3")
+	spender := ethcommon.HexToAddress("0x00000000000000000000000000000000000001c5")
+	permit := ics20transfer.ISignatureTransferPermitTransferFrom{
+		Permitted: ics20transfer.ISignatureTransferTokenPermissions{
+			Token:  ethcommon.HexToAddress("0x00000000000000000000000000000000000e2c20"),
+			Amount: big.NewInt(1_000_000),
+		},
+		Nonce:    big.NewInt(42),
+		Deadline: big.NewInt(1_700_000_000),
+	}
+
+	signature, err := signPermit2Transfer(wallet, chainID, permit2Address, spender, permit)
+	require.NoError(t, err)
+	require.Len(t, signature, crypto.SignatureLength)
+	require.Contains(t, []byte{27, 28}, signature[crypto.RecoveryIDOffset])
+
+	// The digest Permit2 computes on chain, built by hand from the EIP-712 type hashes
+	word := func(value *big.Int) []byte { return ethcommon.LeftPadBytes(value.Bytes(), 32) }
+	tokenPermissionsTypeHash := crypto.Keccak256([]byte("TokenPermissions(address token,uint256 amount)"))
+	permitTypeHash := crypto.Keccak256([]byte("PermitTransferFrom(TokenPermissions permitted,address spender,uint256 nonce,uint256 deadline)TokenPermissions(address token,uint256 amount)"))
+	domainTypeHash := crypto.Keccak256([]byte("EIP712Domain(string name,uint256 chainId,address verifyingContract)"))
+
+	domainSeparator := crypto.Keccak256(domainTypeHash, crypto.Keccak256([]byte("Permit2")), word(chainID), ethcommon.LeftPadBytes(permit2Address.Bytes(), 32))
+	tokenPermissionsHash := crypto.Keccak256(tokenPermissionsTypeHash, ethcommon.LeftPadBytes(permit.Permitted.Token.Bytes(), 32), word(permit.Permitted.Amount))
+	structHash := crypto.Keccak256(permitTypeHash, tokenPermissionsHash, ethcommon.LeftPadBytes(spender.Bytes(), 32), word(permit.Nonce), word(permit.Deadline))
+	digest := crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, structHash)
+
+	// Undo the ecrecover v offset to recover the public key
+	recoverable := slices.Clone(signature)
+	recoverable[crypto.RecoveryIDOffset] -= 27
+	pubKey, err := crypto.SigToPub(digest, recoverable)
+	require.NoError(t, err)
+	require.Equal(t, wallet.address, crypto.PubkeyToAddress(*pubKey))
+}
@@ -7,139 +7,206 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/gjermundgaraba/libibc/utils"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
 )
 
+// maxErrorBodySize is how much of an error response body is kept in the APIError
+const maxErrorBodySize = 1024
+
+// RetryConfig configures how failed requests are retried, with exponential backoff.
+// Only retryable errors (see IsRetryable) are retried.
+type RetryConfig struct {
+	// MaxAttempts is the number of times a request is tried, including the first. Values below 1 mean 1.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Multiplier is what the backoff is multiplied by after every attempt. Values below 1 mean 1.
+	Multiplier float64
+}
+
+// DefaultRetryConfig returns the default retry config, which gives up after roughly half a minute.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:    5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+	}
+}
+
+// backoff returns how long to wait after the failed attempt (starting at 1)
+func (r RetryConfig) backoff(attempt int) time.Duration {
+	backoff := float64(r.InitialBackoff)
+	for range attempt - 1 {
+		backoff *= max(r.Multiplier, 1)
+	}
+
+	if r.MaxBackoff > 0 && backoff > float64(r.MaxBackoff) {
+		return r.MaxBackoff
+	}
+
+	return time.Duration(backoff)
+}
+
 type Client struct {
-	ctx    context.Context
-	cancel context.CancelFunc
+	url        string
+	httpClient *http.Client
+	logger     *zap.Logger
 
-	url string
+	Retry RetryConfig
+}
 
-	Retries   int
-	RetryWait time.Duration
+func NewBeaconAPIClient(logger *zap.Logger, beaconAPIAddress string) (*Client, error) {
+	return NewBeaconAPIClientWithAuth(logger, beaconAPIAddress, utils.HTTPAuth{})
 }
 
-func NewBeaconAPIClient(beaconAPIAddress string) (Client, error) {
-	return Client{
-		url:       beaconAPIAddress,
-		Retries:   60,
-		RetryWait: 10 * time.Second,
+// NewBeaconAPIClientWithAuth creates a beacon API client that sends the auth (e.g. an API key header) with every request.
+func NewBeaconAPIClientWithAuth(logger *zap.Logger, beaconAPIAddress string, auth utils.HTTPAuth) (*Client, error) {
+	if err := auth.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid beacon api auth")
+	}
+
+	// The client has its own transport, so Close only closes its own connections
+	var transport http.RoundTripper = http.DefaultTransport.(*http.Transport).Clone()
+	if !auth.IsZero() {
+		transport = auth.Transport(transport)
+	}
+
+	return &Client{
+		url:        beaconAPIAddress,
+		httpClient: &http.Client{Transport: transport},
+		logger:     logger,
+		Retry:      DefaultRetryConfig(),
 	}, nil
 }
 
-func (b Client) GetBeaconAPIURL() string {
+func (b *Client) GetBeaconAPIURL() string {
 	return b.url
 }
 
-func (b Client) Close() {
-	b.cancel()
+// Close closes the idle connections to the beacon API.
+func (b *Client) Close() {
+	b.httpClient.CloseIdleConnections()
 }
 
-func makeRequest[T any](url string, retries int, waitTime time.Duration) (*T, error) {
-	return retry(retries, waitTime, func() (*T, error) {
-		req, err := http.NewRequest("GET", url, nil)
+// makeRequest gets the url and decodes the JSON response, retrying retryable errors as configured.
+func makeRequest[T any](ctx context.Context, b *Client, url string) (*T, error) {
+	return retry(ctx, b, url, func() (*T, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrapf(err, "failed to create request for %s", url)
 		}
 		req.Header.Set("Accept", "application/json")
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := b.httpClient.Do(req)
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrapf(err, "request to %s failed", url)
 		}
 		defer resp.Body.Close()
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		if resp.StatusCode != 200 {
-			return nil, fmt.Errorf("get bootstrap (%s) failed with status code: %d, body: %s", url, resp.StatusCode, body)
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+			return nil, &APIError{URL: url, StatusCode: resp.StatusCode, Body: string(body)}
 		}
 
 		var data T
-		if err := json.Unmarshal(body, &data); err != nil {
-			return nil, err
+		if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+			return nil, errors.Wrapf(ErrDecode, "response from %s: %s", url, err)
 		}
 
 		return &data, nil
 	})
 }
 
-func retry[T any](retries int, waitTime time.Duration, fn func() (T, error)) (T, error) {
-	var err error
-	var result T
-	for range retries {
-		result, err = fn()
-		if err == nil {
-			return result, nil
+func retry[T any](ctx context.Context, b *Client, url string, fn func() (T, error)) (T, error) {
+	maxAttempts := max(b.Retry.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		result, err := fn()
+		if err == nil || attempt >= maxAttempts || !IsRetryable(err) || ctx.Err() != nil {
+			return result, err
 		}
 
-		fmt.Printf("Retrying for %T: %s in %f seconds\n", result, err, waitTime.Seconds())
-		time.Sleep(waitTime)
+		backoff := b.Retry.backoff(attempt)
+		b.logger.Warn("Beacon API request failed, retrying",
+			zap.String("url", url),
+			zap.Int("attempt", attempt),
+			zap.Int("max_attempts", maxAttempts),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, errors.Wrapf(ctx.Err(), "gave up retrying %s (last error: %s)", url, err)
+		case <-time.After(backoff):
+		}
 	}
-	return result, err
 }
 
 // blockID: Block identifier. Can be one of: "head" (canonical head in node's view), "genesis", "finalized", <slot>, <hex encoded blockRoot with 0x prefix>.
-func (b Client) GetBeaconBlockHeader(blockID string) (*BeaconBlockHeaderResponse, error) {
+func (b *Client) GetBeaconBlockHeader(ctx context.Context, blockID string) (*BeaconBlockHeaderResponse, error) {
 	url := fmt.Sprintf("%s/eth/v1/beacon/headers/%s", b.url, blockID)
-	return makeRequest[BeaconBlockHeaderResponse](url, b.Retries, b.RetryWait)
+	return makeRequest[BeaconBlockHeaderResponse](ctx, b, url)
 }
 
-func (b Client) GetBootstrap(finalizedRoot Root) (*Bootstrap, error) {
+func (b *Client) GetBootstrap(ctx context.Context, finalizedRoot Root) (*Bootstrap, error) {
 	finalizedRootStr := finalizedRoot.String()
 	url := fmt.Sprintf("%s/eth/v1/beacon/light_client/bootstrap/%s", b.url, finalizedRootStr)
 
-	return makeRequest[Bootstrap](url, b.Retries, b.RetryWait)
+	return makeRequest[Bootstrap](ctx, b, url)
 }
 
-func (b Client) GetLightClientUpdates(startPeriod uint64, count uint64) (*LightClientUpdatesResponse, error) {
+func (b *Client) GetLightClientUpdates(ctx context.Context, startPeriod uint64, count uint64) (*LightClientUpdatesResponse, error) {
 	url := fmt.Sprintf("%s/eth/v1/beacon/light_client/updates?start_period=%d&count=%d", b.url, startPeriod, count)
-	return makeRequest[LightClientUpdatesResponse](url, b.Retries, b.RetryWait)
+	return makeRequest[LightClientUpdatesResponse](ctx, b, url)
 }
 
-func (b Client) GetGenesis() (*Genesis, error) {
+func (b *Client) GetGenesis(ctx context.Context) (*Genesis, error) {
 	url := fmt.Sprintf("%s/eth/v1/beacon/genesis", b.url)
-	return makeRequest[Genesis](url, b.Retries, b.RetryWait)
+	return makeRequest[Genesis](ctx, b, url)
 }
 
-func (b Client) GetSpec() (*Spec, error) {
+func (b *Client) GetSpec(ctx context.Context) (*Spec, error) {
 	url := fmt.Sprintf("%s/eth/v1/config/spec", b.url)
-	return makeRequest[Spec](url, b.Retries, b.RetryWait)
+	return makeRequest[Spec](ctx, b, url)
 }
 
-func (b Client) GetFinalityUpdate() (*FinalityUpdateResponse, error) {
+func (b *Client) GetFinalityUpdate(ctx context.Context) (*FinalityUpdateResponse, error) {
 	url := fmt.Sprintf("%s/eth/v1/beacon/light_client/finality_update", b.url)
-	return makeRequest[FinalityUpdateResponse](url, b.Retries, b.RetryWait)
+	return makeRequest[FinalityUpdateResponse](ctx, b, url)
 }
 
-func (b Client) GetBeaconBlock(blockID string) (*BeaconBlocksResponse, error) {
+func (b *Client) GetBeaconBlock(ctx context.Context, blockID string) (*BeaconBlocksResponse, error) {
 	url := fmt.Sprintf("%s/eth/v2/beacon/blocks/%s", b.url, blockID)
-	return makeRequest[BeaconBlocksResponse](url, b.Retries, b.RetryWait)
+	return makeRequest[BeaconBlocksResponse](ctx, b, url)
 }
 
-func (b Client) GetFinalizedBlocks() (*BeaconBlocksResponse, error) {
-	resp, err := b.GetBeaconBlock("finalized")
+// GetFinalizedBlocks returns the finalized block, or ErrNotFinalized if the node has not finalized a block yet.
+func (b *Client) GetFinalizedBlocks(ctx context.Context) (*BeaconBlocksResponse, error) {
+	resp, err := b.GetBeaconBlock(ctx, "finalized")
 	if err != nil {
 		return nil, err
 	}
 
 	if !resp.Finalized {
-		return nil, fmt.Errorf("block is not finalized")
+		return nil, ErrNotFinalized
 	}
 
 	return resp, nil
 }
 
-func (b Client) GetExecutionHeight(blockID string) (uint64, error) {
-	resp, err := b.GetBeaconBlock(blockID)
+// GetExecutionHeight returns the execution block number of the beacon block.
+// For the "finalized" block id, ErrNotFinalized is returned if the node has not finalized a block yet.
+func (b *Client) GetExecutionHeight(ctx context.Context, blockID string) (uint64, error) {
+	resp, err := b.GetBeaconBlock(ctx, blockID)
 	if err != nil {
 		return 0, err
 	}
 
 	if blockID == "finalized" && !resp.Finalized {
-		return 0, fmt.Errorf("block is not finalized")
+		return 0, ErrNotFinalized
 	}
 
 	return resp.Data.Message.Body.ExecutionPayload.BlockNumber, nil
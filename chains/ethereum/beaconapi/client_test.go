@@ -0,0 +1,125 @@
+package beaconapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewBeaconAPIClient(zap.NewNop(), server.URL)
+	require.NoError(t, err)
+	client.Retry = RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 2}
+	t.Cleanup(client.Close)
+
+	return client
+}
+
+func TestRetryOnServerError(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"finalized": true, "data": {"message": {"slot": "10"}}}`)) //nolint:errcheck
+	})
+
+	resp, err := client.GetFinalizedBlocks(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "10", resp.Data.Message.Slot)
+	require.Equal(t, int32(3), requests.Load())
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, err := client.GetGenesis(context.Background())
+	require.Error(t, err)
+	require.True(t, IsRetryable(err))
+	require.Equal(t, int32(3), requests.Load())
+}
+
+func TestTypedErrors(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/eth/v2/beacon/blocks/finalized" {
+			w.Write([]byte(`{"finalized": false}`)) //nolint:errcheck
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	_, err := client.GetBootstrap(context.Background(), Root{1})
+	require.ErrorIs(t, err, ErrNotFound)
+	require.False(t, IsRetryable(err))
+	require.Equal(t, int32(1), requests.Load(), "a 404 should not be retried")
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+
+	_, err = client.GetFinalizedBlocks(context.Background())
+	require.ErrorIs(t, err, ErrNotFinalized)
+	require.False(t, IsRetryable(err))
+
+	_, err = client.GetExecutionHeight(context.Background(), "finalized")
+	require.ErrorIs(t, err, ErrNotFinalized)
+}
+
+func TestDecodeErrorNotRetried(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`not json`)) //nolint:errcheck
+	})
+
+	_, err := client.GetGenesis(context.Background())
+	require.ErrorIs(t, err, ErrDecode)
+	require.False(t, IsRetryable(err))
+	require.Equal(t, int32(1), requests.Load(), "a response that can't be decoded should not be retried")
+}
+
+func TestContextCancellation(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client.Retry = RetryConfig{MaxAttempts: 100, InitialBackoff: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetSpec(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 10*time.Second)
+}
+
+func TestRetryConfigBackoff(t *testing.T) {
+	retryConfig := RetryConfig{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 2}
+	require.Equal(t, time.Second, retryConfig.backoff(1))
+	require.Equal(t, 2*time.Second, retryConfig.backoff(2))
+	require.Equal(t, 4*time.Second, retryConfig.backoff(3))
+	require.Equal(t, 5*time.Second, retryConfig.backoff(4))
+}
+
+func TestRootString(t *testing.T) {
+	require.Equal(t, "0x0100000000000000000000000000000000000000000000000000000000000000", Root{1}.String())
+}
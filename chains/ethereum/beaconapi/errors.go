@@ -0,0 +1,53 @@
+package beaconapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNotFound is matched (with errors.Is) by an APIError for a 404, e.g. a block or light client bootstrap the node
+	// doesn't have (yet).
+	ErrNotFound = errors.New("not found")
+	// ErrNotFinalized is returned when the finalized block is asked for before the chain has finalized one.
+	// Waiting for the chain to finalize resolves it.
+	ErrNotFinalized = errors.New("block is not finalized")
+	// ErrDecode is returned when a 200 response can't be decoded, e.g. because the node speaks a different version of the API.
+	// Trying again won't change the response, so it is not retried.
+	ErrDecode = errors.New("failed to decode response")
+)
+
+// APIError is returned when the beacon API responds with a status other than 200 OK.
+type APIError struct {
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("beacon api request %s failed with status code %d: %s", e.URL, e.StatusCode, e.Body)
+}
+
+// Is makes errors.Is(err, ErrNotFound) true for a 404.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// IsRetryable returns true if the request can succeed when tried again as is: connection errors, rate limits (429)
+// and server errors (5xx). Other errors, such as ErrNotFound, ErrNotFinalized, ErrDecode or a bad request, are returned right away
+// by the client, for the caller to decide what to do with them.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, ErrNotFinalized) || errors.Is(err, ErrDecode) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	// Anything else failed before getting a response, which is worth another try
+	return true
+}
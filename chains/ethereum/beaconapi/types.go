@@ -12,7 +12,7 @@ type Root [32]byte
 
 // String returns a string version of the structure.
 func (r Root) String() string {
-	return fmt.Sprintf("%#x", r)
+	return fmt.Sprintf("%#x", r[:])
 }
 
 // Genesis provides information about the genesis of a chain.
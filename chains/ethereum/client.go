@@ -0,0 +1,248 @@
+package ethereum
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/ics20transfer"
+	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/ics26router"
+	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/relayerhelper"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// clientHealthCheckInterval is how long a dialed client is used before checking that it still works
+	clientHealthCheckInterval = 30 * time.Second
+	clientHealthCheckTimeout  = 5 * time.Second
+)
+
+// Client is the ethereum RPC client the chain talks to.
+// It is implemented by *ethclient.Client, and by the client of go-ethereum's simulated backend, which is used in tests.
+type Client interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	ethereum.ChainIDReader
+	ethereum.BlockNumberReader
+	ethereum.ChainStateReader
+	ethereum.TransactionReader
+}
+
+var _ Client = &ethclient.Client{}
+
+// contractBindings are the contract bindings for the current client, which are recreated when the client is.
+type contractBindings struct {
+	ics26         *ics26router.Contract
+	ics20         *ics20transfer.Contract
+	relayerHelper *relayerhelper.Contract
+}
+
+// getClient returns the client for the chain.
+// A client dialed from the endpoints is created on first use, kept for later calls and replaced if it stops responding.
+// An injected client is always used as is.
+func (e *Ethereum) getClient() (Client, error) {
+	e.connMu.Lock()
+	client := e.client
+	endpoint := e.clientEndpoint
+	checkDue := client != nil && e.endpoints != nil && time.Since(e.clientCheckedAt) >= clientHealthCheckInterval
+	if checkDue {
+		// Claim the check, so concurrent calls keep using the client instead of checking it as well
+		e.clientCheckedAt = time.Now()
+	}
+	e.connMu.Unlock()
+
+	if client != nil && !checkDue {
+		return client, nil
+	}
+
+	if client != nil {
+		// The check runs without holding connMu, so other calls are not held up by an unresponsive endpoint
+		ctx, cancel := context.WithTimeout(context.Background(), clientHealthCheckTimeout)
+		_, err := client.BlockNumber(ctx)
+		cancel()
+		if err == nil {
+			return client, nil
+		}
+
+		e.logger.Info("Ethereum client failed health check, reconnecting", zap.String("chain_id", e.ChainID), zap.Error(err))
+		if endpoint != "" {
+			e.endpoints.ReportFailure(endpoint, err)
+		}
+	}
+
+	return e.replaceClient(client)
+}
+
+// replaceClient dials a new client to replace the old one (nil if there is none yet), unless another call replaced it first.
+// The old client is not closed right away, since other calls may still be using it.
+func (e *Ethereum) replaceClient(old Client) (Client, error) {
+	if e.endpoints == nil {
+		return nil, errors.Errorf("no ethereum client for chain %s", e.ChainID)
+	}
+
+	ethClient, endpoint, err := e.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	e.connMu.Lock()
+	defer e.connMu.Unlock()
+
+	if e.client != nil && e.client != old {
+		ethClient.Close()
+		return e.client, nil
+	}
+
+	e.client = ethClient
+	e.clientEndpoint = endpoint
+	e.clientCheckedAt = time.Now()
+	e.bindings = contractBindings{}
+
+	if old != nil {
+		e.closeReplacedClient(old)
+	}
+
+	return ethClient, nil
+}
+
+// dial dials the endpoints, and returns the endpoint the client is connected to (empty if it fails over between them).
+// With http(s) endpoints a single client fails over between all of them on every request,
+// otherwise the first endpoint that can be dialed is used.
+func (e *Ethereum) dial() (*ethclient.Client, string, error) {
+	authOpt := rpc.WithHTTPAuth(e.rpcAuth.SetHeaders)
+
+	if e.endpoints.AllHTTP() {
+		httpClient := &http.Client{Transport: e.endpoints.FailoverTransport(nil)}
+		rpcClient, err := rpc.DialOptions(context.Background(), e.endpoints.Primary(), rpc.WithHTTPClient(httpClient), authOpt)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to dial ethereum client")
+		}
+
+		return ethclient.NewClient(rpcClient), "", nil
+	}
+
+	var lastErr error
+	for _, addr := range e.endpoints.Order() {
+		rpcClient, err := rpc.DialOptions(context.Background(), addr, authOpt)
+		if err != nil {
+			e.endpoints.ReportFailure(addr, err)
+			lastErr = err
+			continue
+		}
+
+		return ethclient.NewClient(rpcClient), addr, nil
+	}
+
+	return nil, "", errors.Wrap(lastErr, "failed to dial ethereum client")
+}
+
+// closeReplacedClient closes a replaced client once the calls that may still be using it are done,
+// the longest of which is waiting for a transaction to be mined.
+func (e *Ethereum) closeReplacedClient(client Client) {
+	if closer, ok := client.(interface{ Close() }); ok {
+		time.AfterFunc(2*max(e.mineTimeout(), receiptTimeout), closer.Close)
+	}
+}
+
+// Close implements network.Chain.
+// It closes the dialed client and logs the endpoint stats, while an injected client is left for the caller to close.
+func (e *Ethereum) Close() error {
+	e.connMu.Lock()
+	defer e.connMu.Unlock()
+
+	if e.endpoints != nil {
+		e.endpoints.LogStats()
+		e.closeClientLocked()
+	}
+
+	return nil
+}
+
+func (e *Ethereum) closeClientLocked() {
+	if closer, ok := e.client.(interface{ Close() }); ok {
+		closer.Close()
+	}
+	e.client = nil
+	e.clientEndpoint = ""
+	e.bindings = contractBindings{}
+}
+
+// ics26Contract returns the (cached) ics26 router binding.
+func (e *Ethereum) ics26Contract() (*ics26router.Contract, error) {
+	// Makes sure there is a working client, which the bindings are created for
+	if _, err := e.getClient(); err != nil {
+		return nil, err
+	}
+
+	e.connMu.Lock()
+	defer e.connMu.Unlock()
+
+	if e.client == nil {
+		return nil, errors.Errorf("ethereum client for chain %s is closed", e.ChainID)
+	}
+
+	if e.bindings.ics26 == nil {
+		var err error
+		e.bindings.ics26, err = ics26router.NewContract(e.ics26Address, e.client)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get ics26 contract")
+		}
+	}
+
+	return e.bindings.ics26, nil
+}
+
+// ics20Contract returns the (cached) ics20 transfer binding.
+func (e *Ethereum) ics20Contract() (*ics20transfer.Contract, error) {
+	// Makes sure there is a working client, which the bindings are created for
+	if _, err := e.getClient(); err != nil {
+		return nil, err
+	}
+
+	e.connMu.Lock()
+	defer e.connMu.Unlock()
+
+	if e.client == nil {
+		return nil, errors.Errorf("ethereum client for chain %s is closed", e.ChainID)
+	}
+
+	if e.bindings.ics20 == nil {
+		var err error
+		e.bindings.ics20, err = ics20transfer.NewContract(e.ics20Address, e.client)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get ics20 contract")
+		}
+	}
+
+	return e.bindings.ics20, nil
+}
+
+// relayerHelperContract returns the (cached) relayer helper binding.
+func (e *Ethereum) relayerHelperContract() (*relayerhelper.Contract, error) {
+	// Makes sure there is a working client, which the bindings are created for
+	if _, err := e.getClient(); err != nil {
+		return nil, err
+	}
+
+	e.connMu.Lock()
+	defer e.connMu.Unlock()
+
+	if e.client == nil {
+		return nil, errors.Errorf("ethereum client for chain %s is closed", e.ChainID)
+	}
+
+	if e.bindings.relayerHelper == nil {
+		var err error
+		e.bindings.relayerHelper, err = relayerhelper.NewContract(e.relayerHelperAddress, e.client)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get relayer helper contract")
+		}
+	}
+
+	return e.bindings.relayerHelper, nil
+}
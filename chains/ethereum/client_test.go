@@ -0,0 +1,74 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gjermundgaraba/libibc/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// closeTrackingClient records whether the client was closed
+type closeTrackingClient struct {
+	Client
+	closed atomic.Bool
+}
+
+func (c *closeTrackingClient) Close() {
+	c.closed.Store(true)
+}
+
+// newRPCServer serves the few json-rpc methods needed to create and health check a client.
+func newRPCServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": "0x1"})
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestReplaceClient(t *testing.T) {
+	server := newRPCServer(t)
+	pool, err := utils.NewEndpointPool(zap.NewNop(), "test", []string{server.URL}, utils.SelectionPriority)
+	require.NoError(t, err)
+
+	eth, err := newNonIBCEthereum(context.Background(), zap.NewNop(), "1", pool, utils.HTTPAuth{}, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = eth.Close() })
+
+	old := &closeTrackingClient{Client: eth.client}
+	eth.client = old
+
+	replaced, err := eth.replaceClient(old)
+	require.NoError(t, err)
+	require.NotSame(t, old, replaced)
+	// Calls that got the old client before it was replaced may still be using it
+	require.False(t, old.closed.Load())
+
+	// A call that saw the old client failing after it was already replaced keeps the new client
+	again, err := eth.replaceClient(old)
+	require.NoError(t, err)
+	require.Equal(t, replaced, again)
+
+	current, err := eth.getClient()
+	require.NoError(t, err)
+	require.Equal(t, replaced, current)
+}
@@ -0,0 +1,108 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/gjermundgaraba/libibc/chains/ethereum/weth"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// NativeDenom is the denom used for native ETH (case-insensitive). Any other denom is either the address of an ERC20 contract
+// or the full IBC denom path of a token received over IBC (e.g. transfer/08-wasm-274/uatom), which is resolved to its IBCERC20 contract.
+// Send, GetBalance and SendTransfer all use these semantics.
+const NativeDenom = "eth"
+
+// IsNativeDenom returns true if the denom refers to native ETH.
+func IsNativeDenom(denom string) bool {
+	return strings.EqualFold(denom, NativeDenom)
+}
+
+// parseERC20Denom returns the ERC20 contract address for a denom that is a contract address.
+func parseERC20Denom(denom string) (ethcommon.Address, error) {
+	if !ethcommon.IsHexAddress(denom) {
+		return ethcommon.Address{}, errors.Errorf("invalid denom %s: must be %s, an ERC20 contract address or an IBC denom path", denom, NativeDenom)
+	}
+
+	return ethcommon.HexToAddress(denom), nil
+}
+
+// isIBCDenomPath returns true if the denom looks like a full IBC denom path (port/client/base denom).
+func isIBCDenomPath(denom string) bool {
+	return strings.Contains(denom, "/")
+}
+
+// resolveERC20Denom returns the ERC20 contract address for a denom that is either a contract address or an IBC denom path.
+func (e *Ethereum) resolveERC20Denom(ctx context.Context, denom string) (ethcommon.Address, error) {
+	if isIBCDenomPath(denom) {
+		return e.ResolveIBCDenom(ctx, denom)
+	}
+
+	return parseERC20Denom(denom)
+}
+
+// ResolveIBCDenom returns the address of the IBCERC20 contract the ics20 contract created for the full IBC denom path
+// (e.g. transfer/08-wasm-274/uatom).
+func (e *Ethereum) ResolveIBCDenom(ctx context.Context, denomPath string) (ethcommon.Address, error) {
+	ics20Contract, err := e.ics20Contract()
+	if err != nil {
+		return ethcommon.Address{}, err
+	}
+
+	erc20Address, err := ics20Contract.IbcERC20Contract(&bind.CallOpts{Context: ctx}, denomPath)
+	if err != nil {
+		return ethcommon.Address{}, errors.Wrapf(err, "failed to query IBCERC20 contract for %s", denomPath)
+	}
+	if erc20Address == (ethcommon.Address{}) {
+		return ethcommon.Address{}, errors.Errorf("no IBCERC20 contract found for %s (has it been transferred to this chain yet?)", denomPath)
+	}
+
+	return erc20Address, nil
+}
+
+// IBCDenomPath returns the full IBC denom path for an IBCERC20 contract created by the ics20 contract.
+func (e *Ethereum) IBCDenomPath(ctx context.Context, erc20Address ethcommon.Address) (string, error) {
+	ics20Contract, err := e.ics20Contract()
+	if err != nil {
+		return "", err
+	}
+
+	denomPath, err := ics20Contract.IbcERC20Denom(&bind.CallOpts{Context: ctx}, erc20Address)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to query IBC denom for %s", erc20Address.String())
+	}
+	if denomPath == "" {
+		return "", errors.Errorf("%s is not an IBCERC20 contract created by the ics20 contract", erc20Address.String())
+	}
+
+	return denomPath, nil
+}
+
+// wrapNative wraps the amount of native ETH into WETH, so it can be transferred over IBC as an ERC20.
+func (e *Ethereum) wrapNative(ctx context.Context, wallet *Wallet, amount *big.Int) (ethcommon.Address, error) {
+	if e.wethAddress == (ethcommon.Address{}) {
+		return ethcommon.Address{}, errors.Errorf("no WETH address configured for chain %s, which is needed to transfer native ETH", e.ChainID)
+	}
+
+	receipt, err := e.Transact(ctx, wallet, func(ethClient Client, txOpts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+		wethContract, err := weth.NewContract(e.wethAddress, ethClient)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get weth contract")
+		}
+
+		txOpts.Value = amount
+		return wethContract.Deposit(txOpts)
+	})
+	if err != nil {
+		return ethcommon.Address{}, errors.Wrap(err, "failed to wrap ETH")
+	}
+
+	e.logger.Info("Wrapped ETH", zap.String("tx_hash", receipt.TxHash.String()), zap.String("amount", amount.String()), zap.String("weth_address", e.wethAddress.String()))
+
+	return e.wethAddress, nil
+}
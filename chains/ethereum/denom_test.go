@@ -0,0 +1,45 @@
+package ethereum
+
+import (
+	"context"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveERC20Denom(t *testing.T) {
+	eth := &Ethereum{}
+
+	erc20Address, err := eth.resolveERC20Denom(context.Background(), "0x3aF134307D5Ee90faa2ba9Cdba14ba66414CF1A7")
+	require.NoError(t, err)
+	require.Equal(t, ethcommon.HexToAddress("0x3aF134307D5Ee90faa2ba9Cdba14ba66414CF1A7"), erc20Address)
+
+	_, err = eth.resolveERC20Denom(context.Background(), "uatom")
+	require.Error(t, err)
+
+	require.True(t, IsNativeDenom("ETH"))
+	require.True(t, isIBCDenomPath("transfer/08-wasm-274/uatom"))
+	require.False(t, isIBCDenomPath("0x3aF134307D5Ee90faa2ba9Cdba14ba66414CF1A7"))
+}
+
+func TestSimulatedResolveIBCDenom(t *testing.T) {
+	ctx := context.Background()
+	chain := newSimChain(t)
+
+	// Nothing has been received over IBC, so there is no IBCERC20 contract for the denom path
+	const denomPath = "transfer/" + simSourceClient + "/uatom"
+	_, err := chain.ResolveIBCDenom(ctx, denomPath)
+	require.Error(t, err)
+
+	// IBC denom paths are resolved through the ics20 contract, and contract addresses are used as they are
+	_, err = chain.resolveERC20Denom(ctx, denomPath)
+	require.ErrorContains(t, err, denomPath)
+	erc20Address, err := chain.resolveERC20Denom(ctx, chain.contracts.Erc20.String())
+	require.NoError(t, err)
+	require.Equal(t, chain.contracts.Erc20, erc20Address)
+
+	// The test ERC20 was not created by the ics20 contract, so it has no IBC denom path
+	_, err = chain.IBCDenomPath(ctx, chain.contracts.Erc20)
+	require.Error(t, err)
+}
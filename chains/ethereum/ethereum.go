@@ -5,14 +5,17 @@ import (
 	"crypto/ecdsa"
 	"encoding/hex"
 	"math/big"
+	"sync"
+	"time"
 
-	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/ics26router"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gjermundgaraba/libibc/chains/ethereum/beaconapi"
 	"github.com/gjermundgaraba/libibc/chains/ethereum/erc20"
 	"github.com/gjermundgaraba/libibc/chains/ethereum/solidity"
 	"github.com/gjermundgaraba/libibc/chains/network"
+	"github.com/gjermundgaraba/libibc/utils"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -26,22 +29,46 @@ type Ethereum struct {
 	logger  *zap.Logger
 
 	actualChainID *big.Int
-	ethRPC        string
+	// endpoints are the rpc addresses the client is dialed from, nil if the client was injected
+	endpoints *utils.EndpointPool
+	rpcAuth   utils.HTTPAuth
+	beaconAPI *beaconapi.Client
+
+	// connMu guards the client and the contract bindings created from it
+	connMu          sync.Mutex
+	client          Client
+	clientCheckedAt time.Time
+	clientEndpoint  string // The endpoint the client is connected to, if it can't fail over between them
+	bindings        contractBindings
 
 	ics26Address         ethcommon.Address
 	ics20Address         ethcommon.Address
 	relayerHelperAddress ethcommon.Address
-	extraGwei            int64
+	wethAddress          ethcommon.Address
+	feeConfig            FeeConfig
+	nonces               *nonceManager
+	approvalStrategy     ApprovalStrategy
+
+	// receiptPollInterval is how often to check if a sent transaction has been mined
+	receiptPollInterval time.Duration
 }
 
+// NewEthereumWithDeploy creates an Ethereum chain with freshly deployed IBC contracts (see solidity.DeployIBC).
+// The test ERC20 supply is minted to the faucet, which is also added as a wallet.
 func NewEthereumWithDeploy(
 	ctx context.Context,
 	logger *zap.Logger,
 	chainID string,
 	ethRPC string,
 	faucetPrivKey *ecdsa.PrivateKey,
+	deployOpts solidity.DeployOptions,
 ) (*Ethereum, error) {
-	eth, err := newNonIBCEthereum(ctx, logger, chainID, ethRPC)
+	endpoints, err := utils.NewEndpointPool(logger, chainID+" rpc", []string{ethRPC}, utils.SelectionPriority)
+	if err != nil {
+		return nil, err
+	}
+
+	eth, err := newNonIBCEthereum(ctx, logger, chainID, endpoints, utils.HTTPAuth{}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -53,89 +80,172 @@ func NewEthereumWithDeploy(
 	deployerPrivKey := deployerWallet.(*Wallet).privateKey
 
 	faucetPrivKeyHex := hex.EncodeToString(crypto.FromECDSA(faucetPrivKey))
-	eth.AddWallet("faucet", faucetPrivKeyHex)
-	faucetPubKey := faucetPrivKey.PublicKey
-
-	ibcContractAddresses, err := solidity.DeployIBC(
-		ethRPC,
-		deployerPrivKey,
-		faucetPubKey,
-	)
-
-	ethClient, err := ethclient.Dial(ethRPC)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to dial ethereum client")
+	if err := eth.AddWallet("faucet", faucetPrivKeyHex); err != nil {
+		return nil, err
 	}
 
-	eth.ics26Address = ethcommon.HexToAddress(ibcContractAddresses.Ics26Router)
-	router, err := ics26router.NewContract(eth.ics26Address, ethClient)
+	ethClient, err := eth.getClient()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get ics26 router contract")
+		return nil, err
 	}
 
-	eth.ics20Address, err = router.GetIBCApp(nil, "transfer")
+	contracts, err := solidity.DeployIBC(ctx, ethClient, deployerPrivKey, crypto.PubkeyToAddress(faucetPrivKey.PublicKey), deployOpts)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get ics20 address")
+		return nil, errors.Wrap(err, "failed to deploy ibc contracts")
 	}
 
-	eth.relayerHelperAddress = ethcommon.HexToAddress(ibcContractAddresses.RelayerHelper)
+	logger.Info("Deployed IBC contracts",
+		zap.String("ics26_router", contracts.Ics26Router.String()),
+		zap.String("ics20_transfer", contracts.Ics20Transfer.String()),
+		zap.String("relayer_helper", contracts.RelayerHelper.String()),
+		zap.String("ics07_tendermint", contracts.Ics07Tendermint.String()),
+		zap.String("erc20", contracts.Erc20.String()))
+
+	eth.ics26Address = contracts.Ics26Router
+	eth.ics20Address = contracts.Ics20Transfer
+	eth.relayerHelperAddress = contracts.RelayerHelper
 
 	return eth, nil
 }
 
 func NewEthereum(ctx context.Context, logger *zap.Logger, chainID string, ethRPC string, ics26AddressHex string, relayerHelperAddressHex string) (*Ethereum, error) {
-	eth, err := newNonIBCEthereum(ctx, logger, chainID, ethRPC)
+	endpoints, err := utils.NewEndpointPool(logger, chainID+" rpc", []string{ethRPC}, utils.SelectionPriority)
 	if err != nil {
 		return nil, err
 	}
 
-	ethClient, err := ethclient.Dial(ethRPC)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to dial ethereum client")
-	}
+	return NewEthereumWithEndpoints(ctx, logger, chainID, endpoints, utils.HTTPAuth{}, ics26AddressHex, relayerHelperAddressHex)
+}
 
-	eth.ics26Address = ethcommon.HexToAddress(ics26AddressHex)
-	router, err := ics26router.NewContract(eth.ics26Address, ethClient)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get ics26 router contract")
+// NewEthereumWithEndpoints creates an Ethereum chain that fails over between the rpc endpoints of the pool,
+// sending the auth with every request.
+// With http(s) endpoints every request fails over, while with websocket or IPC endpoints the client is re-dialed
+// to the next endpoint when it fails a health check.
+func NewEthereumWithEndpoints(
+	ctx context.Context,
+	logger *zap.Logger,
+	chainID string,
+	endpoints *utils.EndpointPool,
+	rpcAuth utils.HTTPAuth,
+	ics26AddressHex string,
+	relayerHelperAddressHex string,
+) (*Ethereum, error) {
+	if err := rpcAuth.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid rpc auth for chain %s", chainID)
 	}
 
-	eth.ics20Address, err = router.GetIBCApp(nil, "transfer")
+	eth, err := newNonIBCEthereum(ctx, logger, chainID, endpoints, rpcAuth, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get ics20 address")
+		return nil, err
 	}
 
-	eth.relayerHelperAddress = ethcommon.HexToAddress(relayerHelperAddressHex)
+	if err := eth.setIBCAddresses(ctx, ics26AddressHex, relayerHelperAddressHex); err != nil {
+		return nil, err
+	}
 
 	return eth, nil
 }
 
-func newNonIBCEthereum(ctx context.Context, logger *zap.Logger, chainID string, ethRPC string) (*Ethereum, error) {
-	ethClient, err := ethclient.Dial(ethRPC)
+// NewEthereumWithClient creates an Ethereum chain that uses the client instead of dialing an RPC address,
+// e.g. the client of go-ethereum's simulated backend.
+func NewEthereumWithClient(ctx context.Context, logger *zap.Logger, chainID string, client Client, ics26AddressHex string, relayerHelperAddressHex string) (*Ethereum, error) {
+	eth, err := newNonIBCEthereum(ctx, logger, chainID, nil, utils.HTTPAuth{}, client)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to dial ethereum client")
+		return nil, err
 	}
 
-	ethChainID, err := ethClient.ChainID(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get ethereum chain ID")
+	if err := eth.setIBCAddresses(ctx, ics26AddressHex, relayerHelperAddressHex); err != nil {
+		return nil, err
 	}
 
-	return &Ethereum{
+	return eth, nil
+}
+
+// newNonIBCEthereum creates the chain without any of the ibc contracts set. If client is nil, the endpoints are dialed when needed.
+func newNonIBCEthereum(ctx context.Context, logger *zap.Logger, chainID string, endpoints *utils.EndpointPool, rpcAuth utils.HTTPAuth, client Client) (*Ethereum, error) {
+	eth := &Ethereum{
 		ChainID: chainID,
 		Clients: make(map[string]network.ClientCounterparty),
 		Wallets: make(map[string]Wallet),
 
-		actualChainID: ethChainID,
-		ethRPC:        ethRPC,
-		logger:        logger,
+		endpoints:           endpoints,
+		rpcAuth:             rpcAuth,
+		client:              client,
+		logger:              logger,
+		feeConfig:           DefaultFeeConfig(),
+		nonces:              newNonceManager(),
+		receiptPollInterval: time.Second,
+
+		approvalStrategy: ApprovalStrategyApprove,
 
 		// The ibc related fields should be set by the caller
-	}, nil
+	}
+
+	ethClient, err := eth.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	eth.actualChainID, err = ethClient.ChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get ethereum chain ID")
+	}
+
+	return eth, nil
+}
+
+// setIBCAddresses sets the ics26 router and relayer helper, and looks up the ics20 transfer app from the router.
+func (e *Ethereum) setIBCAddresses(ctx context.Context, ics26AddressHex string, relayerHelperAddressHex string) error {
+	e.ics26Address = ethcommon.HexToAddress(ics26AddressHex)
+	e.relayerHelperAddress = ethcommon.HexToAddress(relayerHelperAddressHex)
+
+	router, err := e.ics26Contract()
+	if err != nil {
+		return err
+	}
+
+	ics20Address, err := router.GetIBCApp(&bind.CallOpts{Context: ctx}, "transfer")
+	if err != nil {
+		return errors.Wrap(err, "failed to get ics20 address")
+	}
+
+	e.connMu.Lock()
+	defer e.connMu.Unlock()
+	e.ics20Address = ics20Address
+	e.bindings.ics20 = nil
+
+	return nil
 }
 
 func (e *Ethereum) SetExtraGwei(extraGwei int64) {
-	e.extraGwei = extraGwei
+	e.feeConfig.ExtraGwei = extraGwei
+}
+
+func (e *Ethereum) SetFeeConfig(feeConfig FeeConfig) {
+	e.feeConfig = feeConfig
+}
+
+func (e *Ethereum) SetApprovalStrategy(approvalStrategy ApprovalStrategy) {
+	e.approvalStrategy = approvalStrategy
+}
+
+// SetBeaconAPI sets the beacon API client of the chain's consensus layer.
+func (e *Ethereum) SetBeaconAPI(beaconAPI *beaconapi.Client) {
+	e.beaconAPI = beaconAPI
+}
+
+// BeaconAPI returns the beacon API client of the chain's consensus layer, if one is configured.
+func (e *Ethereum) BeaconAPI() (*beaconapi.Client, error) {
+	if e.beaconAPI == nil {
+		return nil, errors.Errorf("no beacon api configured for chain %s", e.ChainID)
+	}
+
+	return e.beaconAPI, nil
+}
+
+// SetWETHAddress sets the WETH contract used to wrap native ETH for IBC transfers.
+func (e *Ethereum) SetWETHAddress(wethAddress ethcommon.Address) {
+	e.wethAddress = wethAddress
 }
 
 // GetChainID implements network.Chain.
@@ -155,15 +265,15 @@ func (e *Ethereum) GetClients() map[string]network.ClientCounterparty {
 
 // GetBalance implements network.Chain.
 func (e *Ethereum) GetBalance(ctx context.Context, address string, denom string) (*big.Int, error) {
-	client, err := ethclient.Dial(e.ethRPC)
+	client, err := e.getClient()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to dial ethereum client")
+		return nil, err
 	}
 
 	ethAddress := ethcommon.HexToAddress(address)
 
 	// Handle ETH balance
-	if denom == "eth" || denom == "ETH" {
+	if IsNativeDenom(denom) {
 		balance, err := client.BalanceAt(ctx, ethAddress, nil)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to query ETH balance for address %s", address)
@@ -172,7 +282,10 @@ func (e *Ethereum) GetBalance(ctx context.Context, address string, denom string)
 	}
 
 	// Handle ERC20 token balance
-	erc20Address := ethcommon.HexToAddress(denom)
+	erc20Address, err := e.resolveERC20Denom(ctx, denom)
+	if err != nil {
+		return nil, err
+	}
 	erc20, err := erc20.NewContract(erc20Address, client)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create ERC20 contract instance for %s", denom)
@@ -185,3 +298,59 @@ func (e *Ethereum) GetBalance(ctx context.Context, address string, denom string)
 
 	return balance, nil
 }
+
+// GetAllBalances implements network.Chain.
+// ERC20 balances can't be enumerated from an address alone, so only the native ETH balance (and WETH, if configured) is returned.
+func (e *Ethereum) GetAllBalances(ctx context.Context, address string) (map[string]*big.Int, error) {
+	ethBalance, err := e.GetBalance(ctx, address, NativeDenom)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := map[string]*big.Int{NativeDenom: ethBalance}
+	if e.wethAddress != (ethcommon.Address{}) {
+		wethBalance, err := e.GetBalance(ctx, address, e.wethAddress.String())
+		if err != nil {
+			return nil, err
+		}
+		balances[e.wethAddress.String()] = wethBalance
+	}
+
+	return balances, nil
+}
+
+// GetTokenMetadata implements network.Chain.
+// Native ETH has 18 decimals, ERC20 tokens (including IBC denom paths) are looked up on the token contract.
+func (e *Ethereum) GetTokenMetadata(ctx context.Context, denom string) (network.TokenMetadata, error) {
+	if IsNativeDenom(denom) {
+		return network.TokenMetadata{Denom: denom, Symbol: "ETH", Decimals: 18}, nil
+	}
+
+	erc20Address, err := e.resolveERC20Denom(ctx, denom)
+	if err != nil {
+		return network.TokenMetadata{}, err
+	}
+
+	client, err := e.getClient()
+	if err != nil {
+		return network.TokenMetadata{}, err
+	}
+
+	erc20, err := erc20.NewContract(erc20Address, client)
+	if err != nil {
+		return network.TokenMetadata{}, errors.Wrapf(err, "failed to create ERC20 contract instance for %s", denom)
+	}
+
+	callOpts := &bind.CallOpts{Context: ctx}
+	decimals, err := erc20.Decimals(callOpts)
+	if err != nil {
+		return network.TokenMetadata{}, errors.Wrapf(err, "failed to query decimals for token %s", denom)
+	}
+
+	symbol, err := erc20.Symbol(callOpts)
+	if err != nil {
+		return network.TokenMetadata{}, errors.Wrapf(err, "failed to query symbol for token %s", denom)
+	}
+
+	return network.TokenMetadata{Denom: denom, Symbol: symbol, Decimals: decimals}, nil
+}
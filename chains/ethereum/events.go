@@ -21,3 +21,17 @@ func GetEvmEvent[T any](receipt *ethtypes.Receipt, parseFn func(log ethtypes.Log
 
 	return
 }
+
+// GetEvmEvents parses the logs in the given receipt and returns every event that can be parsed, in log order.
+// Each event keeps its raw log (including the log index) if the event type has one, as the generated bindings do.
+func GetEvmEvents[T any](receipt *ethtypes.Receipt, parseFn func(log ethtypes.Log) (*T, error)) []*T {
+	var events []*T
+	for _, l := range receipt.Logs {
+		event, err := parseFn(*l)
+		if err == nil && event != nil {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
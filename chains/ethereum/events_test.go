@@ -0,0 +1,34 @@
+package ethereum
+
+import (
+	"errors"
+	"testing"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEvmEvents(t *testing.T) {
+	receipt := &ethtypes.Receipt{
+		Logs: []*ethtypes.Log{
+			{Index: 0, Data: []byte("match")},
+			{Index: 1, Data: []byte("other")},
+			{Index: 2, Data: []byte("match")},
+		},
+	}
+	parseFn := func(log ethtypes.Log) (*ethtypes.Log, error) {
+		if string(log.Data) != "match" {
+			return nil, errors.New("not a matching event")
+		}
+		return &log, nil
+	}
+
+	events := GetEvmEvents(receipt, parseFn)
+	require.Len(t, events, 2)
+	require.Equal(t, uint(0), events[0].Index)
+	require.Equal(t, uint(2), events[1].Index)
+
+	event, err := GetEvmEvent(receipt, parseFn)
+	require.NoError(t, err)
+	require.Equal(t, uint(0), event.Index)
+}
@@ -0,0 +1,139 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/pkg/errors"
+)
+
+// FeeConfig configures how fees are set on the transactions we send.
+// By default, EIP-1559 (type 2) transactions are used.
+type FeeConfig struct {
+	// LegacyPricing uses a legacy gas price instead of EIP-1559 dynamic fees, for networks that need it
+	LegacyPricing bool
+	// TipMultiplier is applied to the suggested priority fee (or the suggested gas price with legacy pricing)
+	TipMultiplier float64
+	// FeeCapMultiplier is applied to the base fee when computing the fee cap (fee cap = base fee * multiplier + tip)
+	FeeCapMultiplier float64
+	// MaxFeePerGas is the ceiling for the fee cap (or the gas price with legacy pricing), nil means no ceiling
+	MaxFeePerGas *big.Int
+	// ExtraGwei is added to the tip (or the gas price with legacy pricing)
+	ExtraGwei int64
+	// GasLimitMultiplier is applied to gas estimates to get the gas limit, to leave room for state changes between estimation and inclusion
+	GasLimitMultiplier float64
+	// BumpAfter is how long to wait for a transaction to be mined before replacing it with bumped fees, 0 disables replacements
+	BumpAfter time.Duration
+	// BumpPercent is how much the fees are increased by when replacing a transaction (at least 10%, which nodes require)
+	BumpPercent int64
+	// MineTimeout is how long to wait for a transaction, including its replacements, to be mined before giving up
+	// (or until the context is done, if that is sooner)
+	MineTimeout time.Duration
+}
+
+func DefaultFeeConfig() FeeConfig {
+	return FeeConfig{
+		TipMultiplier:      1,
+		FeeCapMultiplier:   2,
+		GasLimitMultiplier: 1.2,
+		BumpAfter:          30 * time.Second,
+		BumpPercent:        20,
+		MineTimeout:        10 * time.Minute,
+	}
+}
+
+// SetTxFees sets the fee fields on the transact opts according to the fee config.
+// For EIP-1559 that is GasTipCap and GasFeeCap, and for legacy pricing GasPrice.
+func SetTxFees(ctx context.Context, ethClient Client, txOpts *bind.TransactOpts, feeConfig FeeConfig) error {
+	extra := new(big.Int).Mul(big.NewInt(feeConfig.ExtraGwei), big.NewInt(params.GWei))
+
+	var baseFee *big.Int
+	if !feeConfig.LegacyPricing {
+		header, err := ethClient.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to get latest header")
+		}
+		baseFee = header.BaseFee
+	}
+
+	// Pre-London chains don't have a base fee, so we fall back to legacy pricing
+	if baseFee == nil {
+		suggestedGasPrice, err := ethClient.SuggestGasPrice(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get suggested gas price")
+		}
+
+		gasPrice := new(big.Int).Add(mulBig(suggestedGasPrice, feeConfig.TipMultiplier), extra)
+		txOpts.GasPrice = capBig(gasPrice, feeConfig.MaxFeePerGas)
+		txOpts.GasTipCap = nil
+		txOpts.GasFeeCap = nil
+
+		return nil
+	}
+
+	suggestedTip, err := ethClient.SuggestGasTipCap(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get suggested gas tip cap")
+	}
+
+	tip := new(big.Int).Add(mulBig(suggestedTip, feeConfig.TipMultiplier), extra)
+	feeCap := new(big.Int).Add(mulBig(baseFee, feeConfig.FeeCapMultiplier), tip)
+	feeCap = capBig(feeCap, feeConfig.MaxFeePerGas)
+	if tip.Cmp(feeCap) > 0 {
+		tip = new(big.Int).Set(feeCap)
+	}
+
+	txOpts.GasPrice = nil
+	txOpts.GasTipCap = tip
+	txOpts.GasFeeCap = feeCap
+
+	return nil
+}
+
+// NewTx creates an unsigned transaction using the fees and nonce from txOpts.
+// If the fee cap is set, a dynamic fee (type 2) transaction is created, otherwise a legacy one.
+func NewTx(chainID *big.Int, txOpts *bind.TransactOpts, to ethcommon.Address, value *big.Int, gasLimit uint64, data []byte) *ethtypes.Transaction {
+	if txOpts.GasFeeCap != nil {
+		return ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     txOpts.Nonce.Uint64(),
+			GasTipCap: txOpts.GasTipCap,
+			GasFeeCap: txOpts.GasFeeCap,
+			Gas:       gasLimit,
+			To:        &to,
+			Value:     value,
+			Data:      data,
+		})
+	}
+
+	return ethtypes.NewTx(&ethtypes.LegacyTx{
+		Nonce:    txOpts.Nonce.Uint64(),
+		GasPrice: txOpts.GasPrice,
+		Gas:      gasLimit,
+		To:       &to,
+		Value:    value,
+		Data:     data,
+	})
+}
+
+func mulBig(x *big.Int, multiplier float64) *big.Int {
+	if multiplier <= 0 || multiplier == 1 {
+		return new(big.Int).Set(x)
+	}
+
+	result, _ := new(big.Float).Mul(new(big.Float).SetInt(x), big.NewFloat(multiplier)).Int(nil)
+	return result
+}
+
+func capBig(x *big.Int, ceiling *big.Int) *big.Int {
+	if ceiling != nil && x.Cmp(ceiling) > 0 {
+		return new(big.Int).Set(ceiling)
+	}
+
+	return x
+}
@@ -0,0 +1,121 @@
+package ethereum
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// nonceManager hands out sequential nonces per wallet, so that several transactions can be in flight from the same wallet at once.
+type nonceManager struct {
+	mu     sync.Mutex
+	nonces map[ethcommon.Address]*walletNonces
+}
+
+type walletNonces struct {
+	synced bool
+	next   uint64
+	// gaps are nonces that were handed out, but never ended up in a transaction, and should be used before next
+	gaps []uint64
+}
+
+func newNonceManager() *nonceManager {
+	return &nonceManager{
+		nonces: make(map[ethcommon.Address]*walletNonces),
+	}
+}
+
+// Next returns the next nonce to use for the address, syncing with the pending nonce on chain the first time.
+func (nm *nonceManager) Next(ctx context.Context, ethClient Client, address ethcommon.Address) (uint64, error) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	wn := nm.get(address)
+	if !wn.synced {
+		if err := wn.sync(ctx, ethClient, address); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(wn.gaps) > 0 {
+		nonce := wn.gaps[0]
+		wn.gaps = wn.gaps[1:]
+		return nonce, nil
+	}
+
+	nonce := wn.next
+	wn.next++
+	return nonce, nil
+}
+
+// Release gives back a nonce that was never used in a broadcasted transaction, so it can be used again.
+func (nm *nonceManager) Release(address ethcommon.Address, nonce uint64) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	wn := nm.get(address)
+	if !wn.synced || nonce >= wn.next || slices.Contains(wn.gaps, nonce) {
+		return
+	}
+
+	wn.gaps = append(wn.gaps, nonce)
+	slices.Sort(wn.gaps)
+}
+
+// Resync resets the nonces for the address to the pending nonce on chain.
+// Used when a nonce turns out to be taken or a transaction was dropped, leaving a gap.
+func (nm *nonceManager) Resync(ctx context.Context, ethClient Client, address ethcommon.Address) error {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	return nm.get(address).sync(ctx, ethClient, address)
+}
+
+func (nm *nonceManager) get(address ethcommon.Address) *walletNonces {
+	wn, ok := nm.nonces[address]
+	if !ok {
+		wn = &walletNonces{}
+		nm.nonces[address] = wn
+	}
+
+	return wn
+}
+
+func (wn *walletNonces) sync(ctx context.Context, ethClient Client, address ethcommon.Address) error {
+	pendingNonce, err := ethClient.PendingNonceAt(ctx, address)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get pending nonce for %s", address.String())
+	}
+
+	wn.synced = true
+	wn.next = pendingNonce
+	wn.gaps = nil
+
+	return nil
+}
+
+// isNonceError returns true if the error means the nonce we used is already taken (by a mined or pending tx).
+func isNonceError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "nonce too low") ||
+		strings.Contains(msg, "replacement transaction underpriced")
+}
+
+// isAlreadyKnownError returns true if the error means the node already has this exact tx,
+// e.g. because a request was re-sent to another endpoint after the first one accepted it.
+func isAlreadyKnownError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "already imported")
+}
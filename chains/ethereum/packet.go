@@ -1,13 +1,18 @@
 package ethereum
 
 import (
+	"cmp"
 	"context"
+	"slices"
 
 	channeltypesv2 "github.com/cosmos/ibc-go/v10/modules/core/04-channel/v2/types"
+	hostv2 "github.com/cosmos/ibc-go/v10/modules/core/24-host/v2"
 	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/ics26router"
 	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/relayerhelper"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/gjermundgaraba/libibc/ibc"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -15,14 +20,14 @@ import (
 
 // GetPackets implements network.Chain.
 func (e *Ethereum) GetPackets(ctx context.Context, txHash string) ([]ibc.Packet, error) {
-	ethClient, err := ethclient.Dial(e.ethRPC)
+	ethClient, err := e.getClient()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to dial ethereum client")
+		return nil, err
 	}
 
-	ics26Contract, err := ics26router.NewContract(e.ics26Address, ethClient)
+	ics26Contract, err := e.ics26Contract()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get ics26 contract")
+		return nil, err
 	}
 
 	receipt, err := ethClient.TransactionReceipt(ctx, ethcommon.HexToHash(txHash))
@@ -30,16 +35,30 @@ func (e *Ethereum) GetPackets(ctx context.Context, txHash string) ([]ibc.Packet,
 		return nil, errors.Wrap(err, "failed to get transaction receipt")
 	}
 
-	sendPacketEvent, err := GetEvmEvent(receipt, ics26Contract.ParseSendPacket)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get write acknowledgement event")
+	// A tx can contain several packets (e.g. multicall or batched transfers), so we get every send packet event from the router
+	var packets []ibc.Packet
+	for _, sendPacketEvent := range GetEvmEvents(receipt, ics26Contract.ParseSendPacket) {
+		if sendPacketEvent.Raw.Address != e.ics26Address {
+			continue
+		}
+
+		packets = append(packets, newPacketFromEvent(sendPacketEvent.Packet, sendPacketEvent.Raw))
 	}
-	if sendPacketEvent == nil {
-		return nil, errors.New("write acknowledgement event not found")
+	if len(packets) == 0 {
+		return nil, errors.Errorf("no send packet events found in tx %s", txHash)
 	}
 
+	slices.SortFunc(packets, func(a, b ibc.Packet) int {
+		return cmp.Compare(a.EventIndex, b.EventIndex)
+	})
+
+	return packets, nil
+}
+
+// newPacketFromEvent creates a packet from the packet in an ics26 router event, and the log of that event.
+func newPacketFromEvent(routerPacket ics26router.IICS26RouterMsgsPacket, log ethtypes.Log) ibc.Packet {
 	var payloads []channeltypesv2.Payload
-	for _, payload := range sendPacketEvent.Packet.Payloads {
+	for _, payload := range routerPacket.Payloads {
 		payloads = append(payloads, channeltypesv2.Payload{
 			SourcePort:      payload.SourcePort,
 			DestinationPort: payload.DestPort,
@@ -50,43 +69,97 @@ func (e *Ethereum) GetPackets(ctx context.Context, txHash string) ([]ibc.Packet,
 	}
 
 	packetData := channeltypesv2.Packet{
-		Sequence:          sendPacketEvent.Packet.Sequence,
-		SourceClient:      sendPacketEvent.Packet.SourceClient,
-		DestinationClient: sendPacketEvent.Packet.DestClient,
-		TimeoutTimestamp:  sendPacketEvent.Packet.TimeoutTimestamp,
+		Sequence:          routerPacket.Sequence,
+		SourceClient:      routerPacket.SourceClient,
+		DestinationClient: routerPacket.DestClient,
+		TimeoutTimestamp:  routerPacket.TimeoutTimestamp,
 		Payloads:          payloads,
 	}
 
 	packet := ibc.NewPacket(
-		txHash,
+		log.TxHash.String(),
 		2,
 		packetData.Sequence,
-		sendPacketEvent.Packet.SourceClient,
-		sendPacketEvent.Packet.DestClient,
+		routerPacket.SourceClient,
+		routerPacket.DestClient,
 		packetData.TimeoutTimestamp,
 		packetData,
 	)
+	packet.EventIndex = log.Index
+	packet.Height = log.BlockNumber
 
-	return []ibc.Packet{packet}, nil
+	return packet
 }
 
 // HasPacketReceipt implements network.Chain.
 func (e *Ethereum) IsPacketReceived(ctx context.Context, packet ibc.Packet) (bool, error) {
-	ethClient, err := ethclient.Dial(e.ethRPC)
+	receipt, err := e.QueryPacketReceipt(ctx, packet.DestinationClient, packet.Sequence)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to dial ethereum client")
+		return false, err
+	}
+	e.logger.Debug("Querying packet receipt", zap.String("dest_client", packet.DestinationClient), zap.Uint64("sequence", packet.Sequence), zap.Binary("receipt", receipt[:]))
+
+	return receipt != [32]byte{}, nil
+}
+
+// IsPacketCommitted returns true if a packet sent from this chain still has a packet commitment, meaning it has not been acked or timed out yet.
+func (e *Ethereum) IsPacketCommitted(ctx context.Context, packet ibc.Packet) (bool, error) {
+	commitment, err := e.QueryPacketCommitment(ctx, packet.SourceClient, packet.Sequence)
+	if err != nil {
+		return false, err
+	}
+
+	return commitment != [32]byte{}, nil
+}
+
+// QueryPacketCommitment returns the commitment for a packet sent from this chain (on the source client), which is removed once the packet is acked or timed out.
+func (e *Ethereum) QueryPacketCommitment(ctx context.Context, clientID string, sequence uint64) ([32]byte, error) {
+	return e.queryCommitment(ctx, hostv2.PacketCommitmentKey(clientID, sequence), func(relayerHelper *relayerhelper.Contract, opts *bind.CallOpts) ([32]byte, error) {
+		return relayerHelper.QueryPacketCommitment(opts, clientID, sequence)
+	})
+}
+
+// QueryPacketReceipt returns the receipt for a packet received on this chain (on the destination client).
+func (e *Ethereum) QueryPacketReceipt(ctx context.Context, clientID string, sequence uint64) ([32]byte, error) {
+	return e.queryCommitment(ctx, hostv2.PacketReceiptKey(clientID, sequence), func(relayerHelper *relayerhelper.Contract, opts *bind.CallOpts) ([32]byte, error) {
+		return relayerHelper.QueryPacketReceipt(opts, clientID, sequence)
+	})
+}
+
+// QueryAckCommitment returns the acknowledgement commitment for a packet received on this chain (on the destination client).
+func (e *Ethereum) QueryAckCommitment(ctx context.Context, clientID string, sequence uint64) ([32]byte, error) {
+	return e.queryCommitment(ctx, hostv2.PacketAcknowledgementKey(clientID, sequence), func(relayerHelper *relayerhelper.Contract, opts *bind.CallOpts) ([32]byte, error) {
+		return relayerHelper.QueryAckCommitment(opts, clientID, sequence)
+	})
+}
+
+// queryCommitment queries a commitment through the relayer helper, or when no relayer helper is configured,
+// reads it directly from the ics26 router storage, where commitments are stored under the keccak256 hash of the path.
+func (e *Ethereum) queryCommitment(ctx context.Context, path []byte, queryRelayerHelper func(*relayerhelper.Contract, *bind.CallOpts) ([32]byte, error)) ([32]byte, error) {
+	callOpts := &bind.CallOpts{Context: ctx}
+	if e.relayerHelperAddress != (ethcommon.Address{}) {
+		relayerHelper, err := e.relayerHelperContract()
+		if err != nil {
+			return [32]byte{}, err
+		}
+
+		commitment, err := queryRelayerHelper(relayerHelper, callOpts)
+		if err != nil {
+			return [32]byte{}, errors.Wrapf(err, "failed to query commitment for path 0x%x through relayer helper", path)
+		}
+
+		return commitment, nil
 	}
 
-	relayerHelper, err := relayerhelper.NewContract(e.relayerHelperAddress, ethClient)
+	ics26Contract, err := e.ics26Contract()
 	if err != nil {
-		return false, errors.Wrap(err, "failed to get relayer helper contract")
+		return [32]byte{}, err
 	}
 
-	receipt, err := relayerHelper.QueryPacketReceipt(nil, packet.DestinationClient, packet.Sequence)
+	commitment, err := ics26Contract.GetCommitment(callOpts, crypto.Keccak256Hash(path))
 	if err != nil {
-		return false, errors.Wrap(err, "failed to query packet receipt")
+		return [32]byte{}, errors.Wrapf(err, "failed to query commitment for path 0x%x from ics26 router", path)
 	}
-	e.logger.Debug("Querying packet receipt", zap.String("dest_client", packet.DestinationClient), zap.Uint64("sequence", packet.Sequence), zap.Binary("receipt", receipt[:]))
 
-	return receipt != [32]byte{}, nil
+	return commitment, nil
 }
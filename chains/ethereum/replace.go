@@ -0,0 +1,232 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/gjermundgaraba/libibc/chains/network"
+	"github.com/gjermundgaraba/libibc/utils"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// errFeeCeilingReached is returned when a transaction can't be replaced because the fees can't be bumped enough without going above the max fee.
+var errFeeCeilingReached = errors.New("fee ceiling reached")
+
+const (
+	// receiptTimeout is how long to wait for the receipt of a transaction that is not replaced with bumped fees
+	receiptTimeout = 120 * time.Second
+	// minBumpPercent is the minimum fee increase nodes accept when replacing a pending transaction
+	minBumpPercent = 10
+)
+
+var _ network.TxCanceller = &Ethereum{}
+
+// waitForMined waits until one of the sent transactions (all with the same nonce) is mined.
+// If none of them are mined within the bump window, the latest one is replaced with a copy that pays higher fees.
+// Returns the receipt of whichever transaction landed and the latest transaction sent.
+func (e *Ethereum) waitForMined(ctx context.Context, ethClient Client, txOpts *bind.TransactOpts, sent ...*ethtypes.Transaction) (*ethtypes.Receipt, *ethtypes.Transaction, error) {
+	lastSentAt := time.Now()
+	canBump := e.feeConfig.BumpAfter > 0
+
+	var receipt *ethtypes.Receipt
+	err := utils.WaitForConditionWithContext(ctx, e.mineTimeout(), e.receiptPollInterval, func() (bool, error) {
+		for _, tx := range sent {
+			r, err := ethClient.TransactionReceipt(ctx, tx.Hash())
+			if err == nil && r != nil {
+				receipt = r
+				return true, nil
+			}
+		}
+
+		if !canBump || time.Since(lastSentAt) < e.feeConfig.BumpAfter {
+			return false, nil
+		}
+
+		latest := sent[len(sent)-1]
+		replacement, err := e.replaceTx(ctx, ethClient, txOpts, latest)
+		lastSentAt = time.Now()
+		switch {
+		case errors.Is(err, errFeeCeilingReached):
+			canBump = false
+			e.logger.Info("Transaction not mined, but fees can't be bumped any further", zap.String("tx_hash", latest.Hash().String()), zap.Error(err))
+		case err != nil:
+			// Most likely one of the sent transactions was mined in the meantime, which is picked up on the next poll
+			e.logger.Debug("Failed to replace transaction", zap.String("tx_hash", latest.Hash().String()), zap.Error(err))
+		default:
+			sent = append(sent, replacement)
+			e.logger.Info("Transaction not mined in time, replaced it with bumped fees",
+				zap.String("tx_hash", latest.Hash().String()),
+				zap.String("replacement_tx_hash", replacement.Hash().String()),
+				zap.Uint64("nonce", replacement.Nonce()),
+				zap.String("gas_fee_cap", replacement.GasFeeCap().String()),
+				zap.String("gas_tip_cap", replacement.GasTipCap().String()))
+		}
+
+		return false, nil
+	})
+
+	latest := sent[len(sent)-1]
+	if err != nil {
+		return nil, latest, errors.Wrapf(err, "failed to wait for receipt for tx %s (with %d replacements)", latest.Hash().String(), len(sent)-1)
+	}
+
+	return receipt, latest, nil
+}
+
+// mineTimeout is how long waitForMined waits for a transaction, falling back to receiptTimeout if the fee config has no timeout.
+func (e *Ethereum) mineTimeout() time.Duration {
+	if e.feeConfig.MineTimeout <= 0 {
+		return receiptTimeout
+	}
+
+	return e.feeConfig.MineTimeout
+}
+
+// replaceTx re-sends the same transaction (same nonce, recipient, value and data) with bumped fees.
+func (e *Ethereum) replaceTx(ctx context.Context, ethClient Client, txOpts *bind.TransactOpts, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+	if tx.To() == nil {
+		return nil, errors.New("replacing contract creation transactions is not supported")
+	}
+
+	replacement, err := e.bumpedTx(ctx, ethClient, tx, *tx.To(), tx.Value(), tx.Gas(), tx.Data())
+	if err != nil {
+		return nil, err
+	}
+
+	return sendTx(ctx, ethClient, txOpts, replacement)
+}
+
+// CancelTx implements network.TxCanceller.
+// It replaces the pending transaction with a 0-value transfer to the wallet itself (using the same nonce), with bumped fees.
+func (e *Ethereum) CancelTx(ctx context.Context, wallet network.Wallet, txHash string) (string, error) {
+	ethereumWallet, ok := wallet.(*Wallet)
+	if !ok {
+		return "", errors.Errorf("invalid wallet type: %T", wallet)
+	}
+
+	ethClient, err := e.getClient()
+	if err != nil {
+		return "", err
+	}
+
+	tx, isPending, err := ethClient.TransactionByHash(ctx, ethcommon.HexToHash(txHash))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get tx %s", txHash)
+	}
+	if !isPending {
+		return "", errors.Errorf("tx %s is not pending", txHash)
+	}
+
+	sender, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(e.actualChainID), tx)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get sender of tx %s", txHash)
+	}
+	if sender != ethereumWallet.address {
+		return "", errors.Errorf("tx %s was sent by %s, not by wallet %s (%s)", txHash, sender.String(), ethereumWallet.id, ethereumWallet.address.String())
+	}
+
+	txOpts, err := newTransactOpts(ctx, ethClient, e.actualChainID, ethereumWallet.privateKey, e.feeConfig)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get transact opts")
+	}
+
+	cancelTx, err := e.bumpedTx(ctx, ethClient, tx, ethereumWallet.address, new(big.Int), 21000, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create cancel tx for %s", txHash)
+	}
+
+	signedCancelTx, err := sendTx(ctx, ethClient, txOpts, cancelTx)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to send cancel tx for %s", txHash)
+	}
+
+	e.logger.Info("Sent cancel tx", zap.String("tx_hash", txHash), zap.String("cancel_tx_hash", signedCancelTx.Hash().String()), zap.Uint64("nonce", tx.Nonce()))
+
+	receipt, _, err := e.waitForMined(ctx, ethClient, txOpts, tx, signedCancelTx)
+	if err != nil {
+		return "", err
+	}
+	if receipt.TxHash == tx.Hash() {
+		return "", errors.Errorf("tx %s was mined before it could be cancelled", txHash)
+	}
+
+	return receipt.TxHash.String(), nil
+}
+
+// bumpedTx creates an unsigned transaction with the same nonce as tx, and fees high enough to replace it.
+// The fees are bumped by the configured percentage, or set to the currently suggested fees if those are higher.
+func (e *Ethereum) bumpedTx(ctx context.Context, ethClient Client, tx *ethtypes.Transaction, to ethcommon.Address, value *big.Int, gasLimit uint64, data []byte) (*ethtypes.Transaction, error) {
+	suggested := &bind.TransactOpts{}
+	if err := SetTxFees(ctx, ethClient, suggested, e.feeConfig); err != nil {
+		return nil, errors.Wrap(err, "failed to get suggested fees")
+	}
+
+	bumpPercent := max(e.feeConfig.BumpPercent, minBumpPercent)
+	txOpts := &bind.TransactOpts{
+		Nonce: new(big.Int).SetUint64(tx.Nonce()),
+	}
+
+	if tx.Type() == ethtypes.DynamicFeeTxType {
+		feeCap, err := bumpFee(tx.GasFeeCap(), suggested.GasFeeCap, bumpPercent, e.feeConfig.MaxFeePerGas)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to bump fee cap")
+		}
+		tip, err := bumpFee(tx.GasTipCap(), suggested.GasTipCap, bumpPercent, feeCap)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to bump tip")
+		}
+
+		txOpts.GasFeeCap = feeCap
+		txOpts.GasTipCap = tip
+	} else {
+		gasPrice, err := bumpFee(tx.GasPrice(), suggested.GasPrice, bumpPercent, e.feeConfig.MaxFeePerGas)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to bump gas price")
+		}
+
+		txOpts.GasPrice = gasPrice
+	}
+
+	return NewTx(e.actualChainID, txOpts, to, value, gasLimit, data), nil
+}
+
+// bumpFee increases the fee by bumpPercent (or to the suggested fee, if that is higher) without going above the ceiling.
+// Returns errFeeCeilingReached if the ceiling keeps the fee from being bumped enough for nodes to accept the replacement.
+func bumpFee(fee *big.Int, suggested *big.Int, bumpPercent int64, ceiling *big.Int) (*big.Int, error) {
+	minReplacementFee := percentOf(fee, 100+minBumpPercent)
+
+	bumped := percentOf(fee, 100+bumpPercent)
+	if suggested != nil && suggested.Cmp(bumped) > 0 {
+		bumped = new(big.Int).Set(suggested)
+	}
+	bumped = capBig(bumped, ceiling)
+
+	if bumped.Cmp(minReplacementFee) < 0 {
+		return nil, errors.Wrapf(errFeeCeilingReached, "can't bump %s by %d%% with a ceiling of %s", fee.String(), minBumpPercent, ceiling.String())
+	}
+
+	return bumped, nil
+}
+
+func percentOf(x *big.Int, percent int64) *big.Int {
+	result := new(big.Int).Mul(x, big.NewInt(percent))
+	return result.Div(result, big.NewInt(100))
+}
+
+func sendTx(ctx context.Context, ethClient Client, txOpts *bind.TransactOpts, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+	signedTx, err := txOpts.Signer(txOpts.From, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign tx")
+	}
+
+	if err := ethClient.SendTransaction(ctx, signedTx); err != nil {
+		return nil, errors.Wrap(err, "failed to send tx")
+	}
+
+	return signedTx, nil
+}
@@ -0,0 +1,149 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/ics20transfer"
+	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/ics26router"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gjermundgaraba/libibc/chains/ethereum/erc20"
+	"github.com/pkg/errors"
+)
+
+var (
+	errorStringSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicSelector       = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+)
+
+// revertErrors are the custom errors from the contracts we interact with, used to decode revert data
+var revertErrors = sync.OnceValue(func() []abi.Error {
+	var abiErrors []abi.Error
+	for _, metaData := range []*bind.MetaData{
+		ics26router.ContractMetaData,
+		ics20transfer.ContractMetaData,
+		erc20.ContractMetaData,
+	} {
+		parsed, err := metaData.GetAbi()
+		if err != nil {
+			// The ABIs are generated, so this should never happen
+			panic(errors.Wrap(err, "failed to parse abi"))
+		}
+
+		for _, abiErr := range parsed.Errors {
+			abiErrors = append(abiErrors, abiErr)
+		}
+	}
+
+	return abiErrors
+})
+
+// RevertReason replays a failed transaction and returns the decoded reason it reverted.
+// The transaction is replayed on top of the state of the block before it was included, so the result can differ
+// if the transaction depended on earlier transactions in the same block.
+func RevertReason(ctx context.Context, ethClient Client, chainID *big.Int, tx *ethtypes.Transaction, blockNumber *big.Int) (string, error) {
+	from, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get tx sender")
+	}
+
+	callMsg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+
+	parentBlock := new(big.Int).Sub(blockNumber, big.NewInt(1))
+	_, err = ethClient.CallContract(ctx, callMsg, parentBlock)
+	if err == nil {
+		return "", errors.Errorf("replaying tx %s did not revert", tx.Hash().String())
+	}
+
+	revertData, ok := ethclient.RevertErrorData(err)
+	if !ok {
+		// Not a revert with data (e.g. out of gas), so the error itself is the best reason we have
+		return err.Error(), nil
+	}
+
+	return DecodeRevert(revertData), nil
+}
+
+// DecodeRevert decodes revert data into a human readable reason.
+// It handles Error(string), panics and the custom errors from the ics26router, ics20transfer and erc20 contracts.
+func DecodeRevert(data []byte) string {
+	if len(data) < 4 {
+		return "execution reverted without a reason"
+	}
+
+	selector := data[:4]
+	switch {
+	case bytes.Equal(selector, errorStringSelector):
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			break
+		}
+		return reason
+	case bytes.Equal(selector, panicSelector):
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			break
+		}
+		return fmt.Sprintf("panic: %s", reason)
+	default:
+		for _, abiErr := range revertErrors() {
+			if !bytes.Equal(abiErr.ID[:4], selector) {
+				continue
+			}
+
+			values, err := abiErr.Unpack(data)
+			if err != nil {
+				break
+			}
+
+			return formatAbiError(abiErr, values)
+		}
+	}
+
+	return fmt.Sprintf("unknown error: 0x%x", data)
+}
+
+func formatAbiError(abiErr abi.Error, values interface{}) string {
+	args, ok := values.([]interface{})
+	if !ok || len(args) == 0 {
+		return abiErr.Name
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(abiErr.Name)
+	buf.WriteString("(")
+	for i, arg := range abiErr.Inputs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(arg.Name)
+		buf.WriteString(": ")
+
+		switch v := args[i].(type) {
+		case ethcommon.Address:
+			buf.WriteString(v.String())
+		case []byte:
+			fmt.Fprintf(&buf, "0x%x", v)
+		default:
+			fmt.Fprintf(&buf, "%v", v)
+		}
+	}
+	buf.WriteString(")")
+
+	return buf.String()
+}
@@ -0,0 +1,70 @@
+package ethereum
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/gjermundgaraba/libibc/chains/ethereum/erc20"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeRevert(t *testing.T) {
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	uintType, err := abi.NewType("uint256", "", nil)
+	require.NoError(t, err)
+
+	errorStringArgs, err := abi.Arguments{{Type: stringType}}.Pack("something went wrong")
+	require.NoError(t, err)
+
+	panicArgs, err := abi.Arguments{{Type: uintType}}.Pack(big.NewInt(0x11))
+	require.NoError(t, err)
+
+	erc20ABI, err := erc20.ContractMetaData.GetAbi()
+	require.NoError(t, err)
+	allowanceErr := erc20ABI.Errors["ERC20InsufficientAllowance"]
+	spender := ethcommon.HexToAddress("0x3aF134307D5Ee90faa2ba9Cdba14ba66414CF1A7")
+	allowanceArgs, err := allowanceErr.Inputs.Pack(spender, big.NewInt(5), big.NewInt(10))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{
+			name:     "no data",
+			data:     nil,
+			expected: "execution reverted without a reason",
+		},
+		{
+			name:     "error string",
+			data:     slices.Concat(errorStringSelector, errorStringArgs),
+			expected: "something went wrong",
+		},
+		{
+			name:     "panic",
+			data:     slices.Concat(panicSelector, panicArgs),
+			expected: "panic: arithmetic underflow or overflow",
+		},
+		{
+			name:     "custom error",
+			data:     slices.Concat(allowanceErr.ID.Bytes()[:4], allowanceArgs),
+			expected: "ERC20InsufficientAllowance(spender: 0x3aF134307D5Ee90faa2ba9Cdba14ba66414CF1A7, allowance: 5, needed: 10)",
+		},
+		{
+			name:     "unknown error",
+			data:     []byte{0xde, 0xad, 0xbe, 0xef},
+			expected: "unknown error: 0xdeadbeef",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, DecodeRevert(tt.data))
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/ics26router"
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gjermundgaraba/libibc/ibc"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// PacketEventType is an ics26 router event that carries a packet.
+type PacketEventType string
+
+const (
+	// PacketEventSendPacket is emitted on the source chain. The client ID is the source client.
+	PacketEventSendPacket PacketEventType = "SendPacket"
+	// PacketEventWriteAcknowledgement is emitted on the destination chain. The client ID is the destination client.
+	PacketEventWriteAcknowledgement PacketEventType = "WriteAcknowledgement"
+	// PacketEventAckPacket is emitted on the source chain. The client ID is the source client.
+	PacketEventAckPacket PacketEventType = "AckPacket"
+	// PacketEventTimeoutPacket is emitted on the source chain. The client ID is the source client.
+	PacketEventTimeoutPacket PacketEventType = "TimeoutPacket"
+)
+
+// DefaultScanBlockRange is the number of blocks queried per eth_getLogs call, which most RPC providers accept
+const DefaultScanBlockRange = 2_000
+
+const (
+	// scanRateLimitRetries is how many times a rate limited log query is retried (with the same range) before giving up
+	scanRateLimitRetries = 5
+	// scanRateLimitBackoff is how long to wait before retrying a rate limited log query, doubled for every retry
+	scanRateLimitBackoff = time.Second
+)
+
+// PacketScanner pages through block ranges to find packet events on the ics26 router, including packets we didn't send ourselves.
+// It keeps track of the next block to scan, so calling Scan again continues where the last scan stopped.
+type PacketScanner struct {
+	eth *Ethereum
+
+	eventType      PacketEventType
+	clientID       string
+	blockRange     uint64
+	nextBlock      uint64
+	checkpointFile string
+}
+
+// NewPacketScanner creates a scanner for the event type, starting at fromBlock.
+// If clientID is set, only events for that client are returned.
+// If checkpointFile is set, progress is saved to it after each block range, and a scanner created with the same file
+// resumes from the saved block instead of fromBlock.
+func (e *Ethereum) NewPacketScanner(eventType PacketEventType, clientID string, fromBlock uint64, checkpointFile string) (*PacketScanner, error) {
+	switch eventType {
+	case PacketEventSendPacket, PacketEventWriteAcknowledgement, PacketEventAckPacket, PacketEventTimeoutPacket:
+	default:
+		return nil, errors.Errorf("unknown packet event type: %s", eventType)
+	}
+
+	scanner := &PacketScanner{
+		eth:            e,
+		eventType:      eventType,
+		clientID:       clientID,
+		blockRange:     DefaultScanBlockRange,
+		nextBlock:      fromBlock,
+		checkpointFile: checkpointFile,
+	}
+
+	if checkpointFile != "" {
+		checkpoint, err := loadScanCheckpoint(checkpointFile)
+		if err != nil {
+			return nil, err
+		}
+		if checkpoint > 0 {
+			scanner.nextBlock = checkpoint
+		}
+	}
+
+	return scanner, nil
+}
+
+// SetBlockRange sets the max number of blocks queried at a time, for RPC providers with stricter limits.
+func (s *PacketScanner) SetBlockRange(blockRange uint64) {
+	s.blockRange = max(blockRange, 1)
+}
+
+// NextBlock returns the next block that will be scanned.
+func (s *PacketScanner) NextBlock() uint64 {
+	return s.nextBlock
+}
+
+// Scan finds the packet events from the next block up to and including toBlock (0 means the latest block).
+// The returned packets have their block height set.
+// If a block range is rejected by the RPC provider (too many results or too large range), it is retried with a smaller range.
+// If the RPC provider rate limits us, the same range is retried after a backoff.
+func (s *PacketScanner) Scan(ctx context.Context, toBlock uint64) ([]ibc.Packet, error) {
+	ethClient, err := s.eth.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ics26Contract, err := s.eth.ics26Contract()
+	if err != nil {
+		return nil, err
+	}
+
+	routerABI, err := ics26router.ContractMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get ics26 abi")
+	}
+
+	topics := [][]ethcommon.Hash{{routerABI.Events[string(s.eventType)].ID}}
+	if s.clientID != "" {
+		// The client ID is an indexed string, so the topic is the hash of it
+		topics = append(topics, []ethcommon.Hash{crypto.Keccak256Hash([]byte(s.clientID))})
+	}
+
+	if toBlock == 0 {
+		toBlock, err = ethClient.BlockNumber(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get latest block number")
+		}
+	}
+
+	var packets []ibc.Packet
+	rateLimitRetries := 0
+	for s.nextBlock <= toBlock {
+		endBlock := min(s.nextBlock+s.blockRange-1, toBlock)
+		logs, err := ethClient.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(s.nextBlock),
+			ToBlock:   new(big.Int).SetUint64(endBlock),
+			Addresses: []ethcommon.Address{s.eth.ics26Address},
+			Topics:    topics,
+		})
+		if err != nil {
+			if isRateLimitError(err) && rateLimitRetries < scanRateLimitRetries {
+				backoff := scanRateLimitBackoff << rateLimitRetries
+				rateLimitRetries++
+				s.eth.logger.Debug("Log query rate limited, retrying after backoff", zap.Uint64("from_block", s.nextBlock), zap.Duration("backoff", backoff), zap.Error(err))
+				select {
+				case <-ctx.Done():
+					return packets, errors.Wrapf(ctx.Err(), "failed to get %s logs from block %d to %d (last error: %s)", s.eventType, s.nextBlock, endBlock, err)
+				case <-time.After(backoff):
+				}
+				continue
+			}
+			if isLogRangeError(err) && s.blockRange > 1 {
+				s.blockRange = max(s.blockRange/2, 1)
+				s.eth.logger.Debug("Log range rejected, retrying with a smaller range", zap.Uint64("from_block", s.nextBlock), zap.Uint64("block_range", s.blockRange), zap.Error(err))
+				continue
+			}
+
+			return packets, errors.Wrapf(err, "failed to get %s logs from block %d to %d", s.eventType, s.nextBlock, endBlock)
+		}
+
+		rateLimitRetries = 0
+		for _, log := range logs {
+			packet, err := s.parsePacket(ics26Contract, log)
+			if err != nil {
+				return packets, err
+			}
+			packets = append(packets, packet)
+		}
+
+		s.eth.logger.Debug("Scanned blocks for packets", zap.String("event_type", string(s.eventType)), zap.Uint64("from_block", s.nextBlock), zap.Uint64("to_block", endBlock), zap.Int("num_logs", len(logs)))
+
+		s.nextBlock = endBlock + 1
+		if err := s.saveCheckpoint(); err != nil {
+			return packets, err
+		}
+	}
+
+	return packets, nil
+}
+
+func (s *PacketScanner) parsePacket(ics26Contract *ics26router.Contract, log ethtypes.Log) (ibc.Packet, error) {
+	var routerPacket ics26router.IICS26RouterMsgsPacket
+	switch s.eventType {
+	case PacketEventSendPacket:
+		event, err := ics26Contract.ParseSendPacket(log)
+		if err != nil {
+			return ibc.Packet{}, errors.Wrapf(err, "failed to parse %s event in tx %s", s.eventType, log.TxHash.String())
+		}
+		routerPacket = event.Packet
+	case PacketEventWriteAcknowledgement:
+		event, err := ics26Contract.ParseWriteAcknowledgement(log)
+		if err != nil {
+			return ibc.Packet{}, errors.Wrapf(err, "failed to parse %s event in tx %s", s.eventType, log.TxHash.String())
+		}
+		routerPacket = event.Packet
+	case PacketEventAckPacket:
+		event, err := ics26Contract.ParseAckPacket(log)
+		if err != nil {
+			return ibc.Packet{}, errors.Wrapf(err, "failed to parse %s event in tx %s", s.eventType, log.TxHash.String())
+		}
+		routerPacket = event.Packet
+	case PacketEventTimeoutPacket:
+		event, err := ics26Contract.ParseTimeoutPacket(log)
+		if err != nil {
+			return ibc.Packet{}, errors.Wrapf(err, "failed to parse %s event in tx %s", s.eventType, log.TxHash.String())
+		}
+		routerPacket = event.Packet
+	}
+
+	return newPacketFromEvent(routerPacket, log), nil
+}
+
+func (s *PacketScanner) saveCheckpoint() error {
+	if s.checkpointFile == "" {
+		return nil
+	}
+
+	if err := os.WriteFile(s.checkpointFile, []byte(strconv.FormatUint(s.nextBlock, 10)), 0o644); err != nil {
+		return errors.Wrapf(err, "failed to save scan checkpoint to %s", s.checkpointFile)
+	}
+
+	return nil
+}
+
+// loadScanCheckpoint returns the next block to scan saved in the checkpoint file, or 0 if there is no checkpoint yet.
+func loadScanCheckpoint(checkpointFile string) (uint64, error) {
+	bz, err := os.ReadFile(checkpointFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to read scan checkpoint from %s", checkpointFile)
+	}
+
+	nextBlock, err := strconv.ParseUint(strings.TrimSpace(string(bz)), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid scan checkpoint in %s", checkpointFile)
+	}
+
+	return nextBlock, nil
+}
+
+// isLogRangeError returns true if the RPC provider rejected a log query because of the block range or number of results.
+// Only known provider messages are matched, as looser ones (e.g. "exceeded") also match rate limit errors.
+func isLogRangeError(err error) bool {
+	if isRateLimitError(err) {
+		return false
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, substr := range []string{"block range", "query returned more than", "range is too large", "log response size exceeded"} {
+		if strings.Contains(msg, substr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRateLimitError returns true if the RPC provider rejected the request because we sent too many of them.
+func isRateLimitError(err error) bool {
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "too many requests") || strings.Contains(msg, "rate limit")
+}
@@ -0,0 +1,59 @@
+package ethereum
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketScannerCheckpoint(t *testing.T) {
+	eth := &Ethereum{}
+	checkpointFile := filepath.Join(t.TempDir(), "checkpoint")
+
+	// Without a saved checkpoint, the scanner starts at fromBlock
+	scanner, err := eth.NewPacketScanner(PacketEventSendPacket, "client-0", 100, checkpointFile)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), scanner.NextBlock())
+
+	scanner.nextBlock = 250
+	require.NoError(t, scanner.saveCheckpoint())
+
+	// A new scanner with the same checkpoint file resumes where the last one stopped
+	resumed, err := eth.NewPacketScanner(PacketEventSendPacket, "client-0", 100, checkpointFile)
+	require.NoError(t, err)
+	require.Equal(t, uint64(250), resumed.NextBlock())
+
+	_, err = eth.NewPacketScanner("RecvPacket", "client-0", 100, "")
+	require.Error(t, err)
+}
+
+func TestIsLogRangeError(t *testing.T) {
+	rangeErrors := []string{
+		"exceed maximum block range: 5000",
+		"query returned more than 10000 results",
+		"block range is too wide",
+		"Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range",
+		"eth_getLogs range is too large, max is 1k blocks",
+	}
+	for _, msg := range rangeErrors {
+		require.True(t, isLogRangeError(errors.New(msg)), msg)
+		require.False(t, isRateLimitError(errors.New(msg)), msg)
+	}
+
+	rateLimitErrors := []error{
+		rpc.HTTPError{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"},
+		errors.New("daily request count exceeded, request rate limited"),
+		errors.New("Too Many Requests"),
+	}
+	for _, err := range rateLimitErrors {
+		require.True(t, isRateLimitError(err), err.Error())
+		require.False(t, isLogRangeError(err), err.Error())
+	}
+
+	require.False(t, isLogRangeError(errors.New("execution reverted")))
+	require.False(t, isRateLimitError(errors.New("execution reverted")))
+}
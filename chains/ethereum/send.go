@@ -3,11 +3,10 @@ package ethereum
 import (
 	"context"
 	"math/big"
-	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/gjermundgaraba/libibc/chains/ethereum/erc20"
 	"github.com/gjermundgaraba/libibc/chains/network"
 	"github.com/pkg/errors"
@@ -19,43 +18,34 @@ func (e *Ethereum) Send(ctx context.Context, wallet network.Wallet, amount *big.
 	if !ok {
 		return "", errors.Errorf("invalid wallet type: %T", wallet)
 	}
-	ethClient, err := ethclient.Dial(e.ethRPC)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to dial ethereum client")
-	}
 
 	to := ethcommon.HexToAddress(toAddress)
-	txOpts, err := GetTransactOpts(ctx, ethClient, e.actualChainID, ethereumWallet.privateKey, 5)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to get transaction options")
-	}
-
-	var tx *ethtypes.Transaction
-	var receipt *ethtypes.Receipt
 
 	// Check if we're sending native ETH or an ERC20 token
-	if strings.ToLower(denom) == "eth" {
+	if IsNativeDenom(denom) {
 		// Native ETH transfer
-		tx = ethtypes.NewTransaction(
-			txOpts.Nonce.Uint64(),
-			to,
-			amount,
-			21000, // Standard gas limit for ETH transfers
-			txOpts.GasPrice,
-			nil, // No data for simple ETH transfers
-		)
-		signedTx, err := txOpts.Signer(txOpts.From, tx)
-		if err != nil {
-			return "", errors.Wrap(err, "failed to sign transaction")
-		}
+		receipt, err := e.Transact(ctx, ethereumWallet, func(ethClient Client, txOpts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+			tx := NewTx(
+				e.actualChainID,
+				txOpts,
+				to,
+				amount,
+				21000, // Standard gas limit for ETH transfers
+				nil,   // No data for simple ETH transfers
+			)
+			signedTx, err := txOpts.Signer(txOpts.From, tx)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to sign transaction")
+			}
 
-		err = ethClient.SendTransaction(ctx, signedTx)
-		if err != nil {
-			return "", errors.Wrap(err, "failed to send transaction")
-		}
-		receipt, err = WaitForReceipt(ctx, ethClient, signedTx.Hash())
+			if err := ethClient.SendTransaction(ctx, signedTx); err != nil {
+				return nil, errors.Wrap(err, "failed to send transaction")
+			}
+
+			return signedTx, nil
+		})
 		if err != nil {
-			return "", errors.Wrap(err, "failed to get transaction receipt")
+			return "", errors.Wrap(err, "failed to send ETH")
 		}
 
 		e.logger.Info("ETH send transaction successful",
@@ -64,39 +54,37 @@ func (e *Ethereum) Send(ctx context.Context, wallet network.Wallet, amount *big.
 			zap.String("to", toAddress),
 			zap.String("amount", amount.String()),
 			zap.String("denom", denom))
-	} else {
-		// ERC20 token transfer
-		erc20Address := ethcommon.HexToAddress(denom)
+
+		return receipt.TxHash.String(), nil
+	}
+
+	// ERC20 token transfer
+	erc20Address, err := e.resolveERC20Denom(ctx, denom)
+	if err != nil {
+		return "", err
+	}
+
+	receipt, err := e.Transact(ctx, ethereumWallet, func(ethClient Client, txOpts *bind.TransactOpts) (*ethtypes.Transaction, error) {
 		contract, err := erc20.NewContract(erc20Address, ethClient)
 		if err != nil {
-			return "", errors.Wrapf(err, "failed to create ERC20 contract instance for %s", denom)
+			return nil, errors.Wrapf(err, "failed to create ERC20 contract instance for %s", denom)
 		}
 
 		// Set gas limit higher for ERC20 transfers
 		txOpts.GasLimit = 100000
 
-		tx, err := contract.Transfer(txOpts, to, amount)
-		if err != nil {
-			return "", errors.Wrapf(err, "failed to send ERC20 transaction for token %s", denom)
-		}
-
-		receipt, err = WaitForReceipt(ctx, ethClient, tx.Hash())
-		if err != nil {
-			return "", errors.Wrap(err, "failed to get transaction receipt")
-		}
-
-		e.logger.Info("ERC20 send transaction successful",
-			zap.String("tx_hash", receipt.TxHash.String()),
-			zap.String("from", wallet.Address()),
-			zap.String("to", toAddress),
-			zap.String("amount", amount.String()),
-			zap.String("token", denom))
+		return contract.Transfer(txOpts, to, amount)
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to send ERC20 transaction for token %s", denom)
 	}
 
-	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
-		return "", errors.New("transaction failed")
-	}
+	e.logger.Info("ERC20 send transaction successful",
+		zap.String("tx_hash", receipt.TxHash.String()),
+		zap.String("from", wallet.Address()),
+		zap.String("to", toAddress),
+		zap.String("amount", amount.String()),
+		zap.String("token", denom))
 
 	return receipt.TxHash.String(), nil
 }
-
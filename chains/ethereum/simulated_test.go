@@ -0,0 +1,325 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/gjermundgaraba/libibc/chains/ethereum/erc20"
+	"github.com/gjermundgaraba/libibc/chains/ethereum/solidity"
+	"github.com/gjermundgaraba/libibc/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	// simSourceClient is the id the router gives the first client added to it
+	simSourceClient = "client-0"
+	simDestClient   = "08-wasm-0"
+)
+
+// simChain is an Ethereum chain on top of go-ethereum's simulated backend, with the IBC contracts deployed by solidity.DeployIBC.
+type simChain struct {
+	*Ethereum
+	backend   *simulated.Backend
+	contracts solidity.DeployedContracts
+	// wallet holds the full supply of the test ERC20
+	wallet *Wallet
+	// poorWallet has ETH for gas, but none of the test ERC20
+	poorWallet *Wallet
+}
+
+// autoCommitClient mines a block for every sent transaction, so the chain behaves like a node with instant blocks
+type autoCommitClient struct {
+	simulated.Client
+	backend *simulated.Backend
+}
+
+func (c autoCommitClient) SendTransaction(ctx context.Context, tx *ethtypes.Transaction) error {
+	if err := c.Client.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	c.backend.Commit()
+
+	return nil
+}
+
+// newSimBackend creates a simulated backend with 100 ETH for each of the keys
+func newSimBackend(t *testing.T, keys ...*ecdsa.PrivateKey) (*simulated.Backend, autoCommitClient) {
+	t.Helper()
+
+	alloc := make(ethtypes.GenesisAlloc)
+	for _, key := range keys {
+		alloc[crypto.PubkeyToAddress(key.PublicKey)] = ethtypes.Account{Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))}
+	}
+
+	backend := simulated.NewBackend(alloc, simulated.WithBlockGasLimit(30_000_000))
+	t.Cleanup(func() {
+		require.NoError(t, backend.Close())
+	})
+
+	return backend, autoCommitClient{Client: backend.Client(), backend: backend}
+}
+
+// newSimChain creates the simulated chain with the IBC contracts and the test ERC20 deployed.
+// The contracts are deployed from the artifacts embedded in the solidity package (see chains/ethereum/solidity/artifacts).
+func newSimChain(t *testing.T) *simChain {
+	t.Helper()
+	ctx := context.Background()
+
+	deployerKey, walletKey, poorWalletKey := newKey(t), newKey(t), newKey(t)
+	backend, client := newSimBackend(t, deployerKey, walletKey, poorWalletKey)
+
+	contracts, err := solidity.DeployIBC(ctx, client, deployerKey, crypto.PubkeyToAddress(walletKey.PublicKey), solidity.DeployOptions{
+		LightClient:          simLightClientGenesis(t),
+		CounterpartyClientID: simDestClient,
+	})
+	require.NoError(t, err, "failed to deploy the IBC contracts")
+
+	eth, err := NewEthereumWithClient(ctx, zap.NewNop(), "sim", client, contracts.Ics26Router.String(), contracts.RelayerHelper.String())
+	require.NoError(t, err)
+	eth.receiptPollInterval = 10 * time.Millisecond
+
+	chain := &simChain{
+		Ethereum:  eth,
+		backend:   backend,
+		contracts: contracts,
+	}
+	chain.wallet = chain.addWallet(t, "wallet", walletKey)
+	chain.poorWallet = chain.addWallet(t, "poor", poorWalletKey)
+
+	return chain
+}
+
+func (c *simChain) addWallet(t *testing.T, walletID string, key *ecdsa.PrivateKey) *Wallet {
+	t.Helper()
+
+	require.NoError(t, c.AddWallet(walletID, hex.EncodeToString(crypto.FromECDSA(key))))
+	wallet, err := c.GetWallet(walletID)
+	require.NoError(t, err)
+
+	return wallet.(*Wallet)
+}
+
+// simLightClientGenesis is a light client genesis for a made up counterparty, verified by the mock SP1 verifier
+func simLightClientGenesis(t *testing.T) solidity.LightClientGenesis {
+	t.Helper()
+
+	heightType := []abi.ArgumentMarshaling{{Name: "revisionNumber", Type: "uint64"}, {Name: "revisionHeight", Type: "uint64"}}
+	trustThresholdType := []abi.ArgumentMarshaling{{Name: "numerator", Type: "uint8"}, {Name: "denominator", Type: "uint8"}}
+	clientStateType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "chainId", Type: "string"},
+		{Name: "trustLevel", Type: "tuple", Components: trustThresholdType},
+		{Name: "latestHeight", Type: "tuple", Components: heightType},
+		{Name: "trustingPeriod", Type: "uint32"},
+		{Name: "unbondingPeriod", Type: "uint32"},
+		{Name: "isFrozen", Type: "bool"},
+		{Name: "zkAlgorithm", Type: "uint8"},
+	})
+	require.NoError(t, err)
+
+	type height struct {
+		RevisionNumber uint64
+		RevisionHeight uint64
+	}
+	type trustThreshold struct {
+		Numerator   uint8
+		Denominator uint8
+	}
+	clientState, err := abi.Arguments{{Type: clientStateType}}.Pack(struct {
+		ChainId         string
+		TrustLevel      trustThreshold
+		LatestHeight    height
+		TrustingPeriod  uint32
+		UnbondingPeriod uint32
+		IsFrozen        bool
+		ZkAlgorithm     uint8
+	}{
+		ChainId:         "simulated-counterparty-1",
+		TrustLevel:      trustThreshold{Numerator: 2, Denominator: 3},
+		LatestHeight:    height{RevisionNumber: 1, RevisionHeight: 1},
+		TrustingPeriod:  solidity.DefaultTrustPeriod,
+		UnbondingPeriod: 2 * solidity.DefaultTrustPeriod,
+	})
+	require.NoError(t, err)
+
+	return solidity.LightClientGenesis{
+		ClientState:        clientState,
+		ConsensusStateHash: crypto.Keccak256Hash([]byte("simulated consensus state")),
+	}
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	return key
+}
+
+func newAddress(t *testing.T) ethcommon.Address {
+	t.Helper()
+
+	return crypto.PubkeyToAddress(newKey(t).PublicKey)
+}
+
+func TestSimulatedSendAndGetBalance(t *testing.T) {
+	ctx := context.Background()
+	chain := newSimChain(t)
+	receiver := newAddress(t).String()
+	token := chain.contracts.Erc20.String()
+
+	oneEth := big.NewInt(params.Ether)
+	_, err := chain.Send(ctx, chain.wallet, oneEth, NativeDenom, receiver)
+	require.NoError(t, err)
+
+	balance, err := chain.GetBalance(ctx, receiver, NativeDenom)
+	require.NoError(t, err)
+	require.Equal(t, oneEth, balance)
+
+	_, err = chain.Send(ctx, chain.wallet, big.NewInt(250_000), token, receiver)
+	require.NoError(t, err)
+
+	balance, err = chain.GetBalance(ctx, receiver, token)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(250_000), balance)
+
+	balance, err = chain.GetBalance(ctx, chain.wallet.Address(), token)
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Sub(abi.MaxUint256, big.NewInt(250_000)), balance)
+
+	// The poor wallet has none of the token, so the transfer reverts
+	_, err = chain.Send(ctx, chain.poorWallet, big.NewInt(1), token, receiver)
+	require.Error(t, err)
+
+	client, err := chain.getClient()
+	require.NoError(t, err)
+	erc20Contract, err := erc20.NewContract(chain.contracts.Erc20, client)
+	require.NoError(t, err)
+	symbol, err := erc20Contract.Symbol(nil)
+	require.NoError(t, err)
+	decimals, err := erc20Contract.Decimals(nil)
+	require.NoError(t, err)
+
+	metadata, err := chain.GetTokenMetadata(ctx, token)
+	require.NoError(t, err)
+	require.Equal(t, symbol, metadata.Symbol)
+	require.Equal(t, decimals, metadata.Decimals)
+}
+
+func TestSimulatedTransactAlreadyKnown(t *testing.T) {
+	ctx := context.Background()
+
+	// Only native ETH is sent, so the IBC contracts are not needed
+	key := newKey(t)
+	backend, client := newSimBackend(t, key)
+	eth, err := newNonIBCEthereum(ctx, zap.NewNop(), "sim", nil, utils.HTTPAuth{}, client)
+	require.NoError(t, err)
+	eth.receiptPollInterval = 10 * time.Millisecond
+	chain := &simChain{Ethereum: eth, backend: backend}
+	chain.wallet = chain.addWallet(t, "wallet", key)
+
+	receiver := newAddress(t)
+	oneEth := big.NewInt(params.Ether)
+
+	// The tx is sent twice, like a request re-sent to another endpoint after the first one accepted it
+	receipt, err := chain.Transact(ctx, chain.wallet, func(_ Client, txOpts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+		signedTx, err := txOpts.Signer(txOpts.From, NewTx(chain.actualChainID, txOpts, receiver, oneEth, 21000, nil))
+		require.NoError(t, err)
+
+		rawClient := chain.backend.Client()
+		require.NoError(t, rawClient.SendTransaction(ctx, signedTx))
+		err = rawClient.SendTransaction(ctx, signedTx)
+		require.Error(t, err)
+		chain.backend.Commit()
+
+		return nil, err
+	})
+	require.NoError(t, err)
+	require.Equal(t, ethtypes.ReceiptStatusSuccessful, receipt.Status)
+
+	// Only the one transfer went through, and the next tx gets the next nonce
+	balance, err := chain.GetBalance(ctx, receiver.String(), NativeDenom)
+	require.NoError(t, err)
+	require.Equal(t, oneEth, balance)
+
+	_, err = chain.Send(ctx, chain.wallet, oneEth, NativeDenom, receiver.String())
+	require.NoError(t, err)
+	balance, err = chain.GetBalance(ctx, receiver.String(), NativeDenom)
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Mul(oneEth, big.NewInt(2)), balance)
+}
+
+func TestSimulatedSendTransfer(t *testing.T) {
+	ctx := context.Background()
+	chain := newSimChain(t)
+	require.Equal(t, chain.contracts.Ics20Transfer, chain.ics20Address)
+	token := chain.contracts.Erc20.String()
+
+	for sequence := uint64(1); sequence <= 2; sequence++ {
+		packet, err := chain.SendTransfer(ctx, simSourceClient, chain.wallet, big.NewInt(100), token, "cosmos1receiver", "")
+		require.NoError(t, err)
+		require.Equal(t, sequence, packet.Sequence)
+		require.Equal(t, simSourceClient, packet.SourceClient)
+		require.Equal(t, simDestClient, packet.DestinationClient)
+		require.NotZero(t, packet.Height)
+
+		packets, err := chain.GetPackets(ctx, packet.TxHash)
+		require.NoError(t, err)
+		require.Len(t, packets, 1)
+		require.Equal(t, packet, packets[0])
+
+		// Sent, but not acked or timed out yet
+		committed, err := chain.IsPacketCommitted(ctx, packet)
+		require.NoError(t, err)
+		require.True(t, committed)
+	}
+
+	// The transferred tokens were escrowed
+	balance, err := chain.GetBalance(ctx, chain.wallet.Address(), token)
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Sub(abi.MaxUint256, big.NewInt(200)), balance)
+}
+
+func TestSimulatedIsPacketReceived(t *testing.T) {
+	ctx := context.Background()
+	chain := newSimChain(t)
+
+	// Nothing has been received from the counterparty
+	packet, err := chain.SendTransfer(ctx, simSourceClient, chain.wallet, big.NewInt(100), chain.contracts.Erc20.String(), "cosmos1receiver", "")
+	require.NoError(t, err)
+	packet.SourceClient, packet.DestinationClient = packet.DestinationClient, packet.SourceClient
+
+	received, err := chain.IsPacketReceived(ctx, packet)
+	require.NoError(t, err)
+	require.False(t, received)
+}
+
+func TestSimulatedCloseKeepsInjectedClient(t *testing.T) {
+	ctx := context.Background()
+	chain := newSimChain(t)
+
+	router, err := chain.ics26Contract()
+	require.NoError(t, err)
+	cachedRouter, err := chain.ics26Contract()
+	require.NoError(t, err)
+	require.Same(t, router, cachedRouter)
+
+	// The injected client belongs to the caller, so the chain can still be used after Close
+	require.NoError(t, chain.Close())
+
+	balance, err := chain.GetBalance(ctx, chain.wallet.Address(), chain.contracts.Erc20.String())
+	require.NoError(t, err)
+	require.Equal(t, abi.MaxUint256, balance)
+}
@@ -0,0 +1,95 @@
+package solidity
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// artifactsFS holds the ABI and creation bytecode of the contracts DeployIBC deploys (artifacts/<Contract>.json),
+// trimmed from the Foundry build of the solidity-ibc-eureka submodule. Embedding them means deploying needs neither
+// forge nor the submodule at runtime. Regenerate them with go generate after updating the submodule.
+//
+//go:generate ./generate_artifacts.sh
+//go:embed artifacts
+var artifactsFS embed.FS
+
+// ErrArtifactNotFound is returned when the artifact of a contract to deploy has not been generated.
+var ErrArtifactNotFound = errors.New("contract artifact not found")
+
+// deployedContracts are the contracts DeployIBC deploys, which all need an embedded artifact.
+// Keep it in sync with the contracts in generate_artifacts.sh.
+var deployedContracts = []string{
+	"ICS26Router",
+	"ICS20Transfer",
+	"Escrow",
+	"IBCERC20",
+	"ERC1967Proxy",
+	"RelayerHelper",
+	"TestERC20",
+	"SP1ICS07Tendermint",
+	"SP1MockVerifier",
+}
+
+// foundryArtifact is the part of a compiled Foundry artifact we need to deploy a contract
+type foundryArtifact struct {
+	ABI      json.RawMessage `json:"abi"`
+	Bytecode struct {
+		Object string `json:"object"`
+	} `json:"bytecode"`
+}
+
+// contractCode returns the ABI and creation bytecode for the contract.
+// The bytecode comes from the abigen binding if it was generated with bytecode, otherwise from the embedded artifact.
+func contractCode(name string, metadata *bind.MetaData) (*abi.ABI, []byte, error) {
+	if metadata != nil && metadata.Bin != "" {
+		contractABI, err := metadata.GetAbi()
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to get %s abi", name)
+		}
+
+		return contractABI, ethcommon.FromHex(metadata.Bin), nil
+	}
+
+	return loadArtifact(artifactsFS, name)
+}
+
+// loadArtifact reads the ABI and creation bytecode of a contract from artifacts/<name>.json in fsys.
+func loadArtifact(fsys fs.FS, name string) (*abi.ABI, []byte, error) {
+	artifactPath := path.Join("artifacts", name+".json")
+	bz, err := fs.ReadFile(fsys, artifactPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, errors.Wrapf(ErrArtifactNotFound, "no artifact for %s (run go generate in chains/ethereum/solidity)", name)
+	}
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read %s artifact", name)
+	}
+
+	var artifact foundryArtifact
+	if err := json.Unmarshal(bz, &artifact); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to parse %s artifact %s", name, artifactPath)
+	}
+
+	contractABI, err := abi.JSON(bytes.NewReader(artifact.ABI))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to parse %s abi", name)
+	}
+
+	bytecode := artifact.Bytecode.Object
+	if strings.Contains(bytecode, "__$") {
+		return nil, nil, errors.Errorf("%s bytecode needs library linking, which is not supported", name)
+	}
+	if len(ethcommon.FromHex(bytecode)) == 0 {
+		return nil, nil, errors.Errorf("%s artifact %s has no bytecode (is it an abstract contract or interface?)", name, artifactPath)
+	}
+
+	return &contractABI, ethcommon.FromHex(bytecode), nil
+}
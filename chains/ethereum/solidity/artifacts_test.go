@@ -0,0 +1,70 @@
+package solidity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func artifactFile(bytecode string) *fstest.MapFile {
+	artifact := `{"abi":[{"type":"constructor","inputs":[],"stateMutability":"nonpayable"}],"bytecode":{"object":"` + bytecode + `"}}`
+	return &fstest.MapFile{Data: []byte(artifact)}
+}
+
+func TestLoadArtifact(t *testing.T) {
+	artifacts := fstest.MapFS{
+		"artifacts/Escrow.json":  artifactFile("0x6080604052"),
+		"artifacts/Linked.json":  artifactFile("0x6080__$0123456789abcdef0123456789abcdef01$__"),
+		"artifacts/IEscrow.json": artifactFile("0x"),
+	}
+
+	contractABI, bytecode, err := loadArtifact(artifacts, "Escrow")
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x60, 0x80, 0x60, 0x40, 0x52}, bytecode)
+	require.NotNil(t, contractABI.Constructor)
+
+	_, _, err = loadArtifact(artifacts, "Linked")
+	require.ErrorContains(t, err, "library linking")
+
+	_, _, err = loadArtifact(artifacts, "IEscrow")
+	require.ErrorContains(t, err, "no bytecode")
+
+	_, _, err = loadArtifact(artifacts, "Missing")
+	require.True(t, errors.Is(err, ErrArtifactNotFound))
+}
+
+// TestEmbeddedArtifacts makes sure DeployIBC works without a Foundry toolchain, i.e. that the artifacts have been
+// generated and committed (see artifacts/README.md).
+func TestEmbeddedArtifacts(t *testing.T) {
+	for _, name := range deployedContracts {
+		t.Run(name, func(t *testing.T) {
+			_, bytecode, err := loadArtifact(artifactsFS, name)
+			require.NoError(t, err)
+			require.NotEmpty(t, bytecode)
+		})
+	}
+}
+
+func TestLoadLightClientGenesis(t *testing.T) {
+	genesisPath := filepath.Join(t.TempDir(), "genesis.json")
+	genesisJSON := `{
+		"trustedClientState": "0x0102",
+		"trustedConsensusState": "0x0304",
+		"updateClientVkey": "0x0000000000000000000000000000000000000000000000000000000000000001",
+		"membershipVkey": "0x0000000000000000000000000000000000000000000000000000000000000002",
+		"ucAndMembershipVkey": "0x0000000000000000000000000000000000000000000000000000000000000003",
+		"misbehaviourVkey": "0x0000000000000000000000000000000000000000000000000000000000000004"
+	}`
+	require.NoError(t, os.WriteFile(genesisPath, []byte(genesisJSON), 0o644))
+
+	genesis, err := LoadLightClientGenesis(genesisPath)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01, 0x02}, genesis.ClientState)
+	require.Equal(t, [32]byte(crypto.Keccak256Hash([]byte{0x03, 0x04})), genesis.ConsensusStateHash)
+	require.Equal(t, byte(3), genesis.UcAndMembershipVkey[31])
+}
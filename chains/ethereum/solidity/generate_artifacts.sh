@@ -0,0 +1,26 @@
+#!/usr/bin/env bash
+# Builds the solidity-ibc-eureka contracts and writes the ABI and creation bytecode of the contracts DeployIBC deploys
+# to artifacts/<Contract>.json, which are embedded in the Go package.
+# The contracts must match deployedContracts in artifacts.go.
+set -euo pipefail
+
+cd "$(dirname "$0")"
+
+contracts=(
+  ICS26Router
+  ICS20Transfer
+  Escrow
+  IBCERC20
+  ERC1967Proxy
+  RelayerHelper
+  TestERC20
+  SP1ICS07Tendermint
+  SP1MockVerifier
+)
+
+(cd solidity-ibc-eureka && forge build)
+
+for contract in "${contracts[@]}"; do
+  jq '{abi: .abi, bytecode: {object: .bytecode.object}}' \
+    "solidity-ibc-eureka/out/${contract}.sol/${contract}.json" > "artifacts/${contract}.json"
+done
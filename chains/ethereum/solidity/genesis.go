@@ -0,0 +1,60 @@
+package solidity
+
+import (
+	"encoding/json"
+	"os"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// LightClientGenesis are the constructor arguments for the ICS07 Tendermint light client.
+type LightClientGenesis struct {
+	UpdateClientVkey    [32]byte
+	MembershipVkey      [32]byte
+	UcAndMembershipVkey [32]byte
+	MisbehaviourVkey    [32]byte
+	// ClientState is the ABI encoded trusted client state
+	ClientState []byte
+	// ConsensusStateHash is the keccak256 hash of the ABI encoded trusted consensus state
+	ConsensusStateHash [32]byte
+	// Verifier is the SP1 verifier contract. If not set, a mock verifier that accepts any proof is deployed.
+	Verifier ethcommon.Address
+}
+
+// lightClientGenesisJSON is the genesis.json format written by the solidity-ibc-eureka operator
+type lightClientGenesisJSON struct {
+	TrustedClientState    hexutil.Bytes  `json:"trustedClientState"`
+	TrustedConsensusState hexutil.Bytes  `json:"trustedConsensusState"`
+	UpdateClientVkey      ethcommon.Hash `json:"updateClientVkey"`
+	MembershipVkey        ethcommon.Hash `json:"membershipVkey"`
+	UcAndMembershipVkey   ethcommon.Hash `json:"ucAndMembershipVkey"`
+	MisbehaviourVkey      ethcommon.Hash `json:"misbehaviourVkey"`
+}
+
+// LoadLightClientGenesis reads a light client genesis file in the format written by `operator genesis`.
+func LoadLightClientGenesis(genesisPath string) (LightClientGenesis, error) {
+	bz, err := os.ReadFile(genesisPath)
+	if err != nil {
+		return LightClientGenesis{}, errors.Wrapf(err, "failed to read light client genesis %s", genesisPath)
+	}
+
+	var genesis lightClientGenesisJSON
+	if err := json.Unmarshal(bz, &genesis); err != nil {
+		return LightClientGenesis{}, errors.Wrapf(err, "failed to parse light client genesis %s", genesisPath)
+	}
+	if len(genesis.TrustedClientState) == 0 || len(genesis.TrustedConsensusState) == 0 {
+		return LightClientGenesis{}, errors.Errorf("light client genesis %s is missing the trusted client or consensus state", genesisPath)
+	}
+
+	return LightClientGenesis{
+		UpdateClientVkey:    genesis.UpdateClientVkey,
+		MembershipVkey:      genesis.MembershipVkey,
+		UcAndMembershipVkey: genesis.UcAndMembershipVkey,
+		MisbehaviourVkey:    genesis.MisbehaviourVkey,
+		ClientState:         genesis.TrustedClientState,
+		ConsensusStateHash:  crypto.Keccak256Hash(genesis.TrustedConsensusState),
+	}, nil
+}
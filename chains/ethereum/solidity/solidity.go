@@ -1,132 +1,274 @@
 package solidity
 
 import (
-	"bytes"
+	"context"
 	"crypto/ecdsa"
-	"encoding/hex"
-	"encoding/json"
-	"fmt"
-	"io"
-	"os"
-	"os/exec"
-	"regexp"
-	"strings"
+	"math/big"
 
 	ibctm "github.com/cosmos/ibc-go/v10/modules/light-clients/07-tendermint"
+	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/ics07tendermint"
+	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/ics20transfer"
+	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/ics26router"
+	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/relayerhelper"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
 )
 
 var DefaultTrustLevel = ibctm.Fraction{Numerator: 2, Denominator: 3}.ToTendermint()
 
 const DefaultTrustPeriod = 1209669
 
-type ForgeScriptReturnValues struct {
-	InternalType string `json:"internal_type"`
-	Value        string `json:"value"`
-}
+// ics20PortID is the port the ics20 transfer app is registered under in the router
+const ics20PortID = "transfer"
+
+// DefaultMerklePrefix is the merkle prefix of the ibc store of a Cosmos SDK chain
+var DefaultMerklePrefix = [][]byte{[]byte("ibc"), []byte("")}
 
-type ForgeDeployOutput struct {
-	Returns map[string]ForgeScriptReturnValues `json:"returns"`
+// Backend is what the contracts are deployed with, e.g. an ethclient.Client or a simulated backend client.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	ChainID(ctx context.Context) (*big.Int, error)
 }
 
+// DeployedContracts are the addresses of the contracts deployed by DeployIBC.
 type DeployedContracts struct {
-	Ics07Tendermint string `json:"ics07Tendermint"`
-	Ics26Router     string `json:"ics26Router"`
-	RelayerHelper   string `json:"relayerHelper"`
-	Ics20Transfer   string `json:"ics20Transfer"`
-	Erc20           string `json:"erc20"`
+	Ics07Tendermint ethcommon.Address
+	Ics26Router     ethcommon.Address
+	RelayerHelper   ethcommon.Address
+	Ics20Transfer   ethcommon.Address
+	Erc20           ethcommon.Address
 }
 
+// DeployOptions configures DeployIBC.
+type DeployOptions struct {
+	// Permit2 is the Permit2 contract used by the ics20 transfer app, if any
+	Permit2 ethcommon.Address
+	// LightClient is the genesis for the ICS07 Tendermint light client of the counterparty chain (required)
+	LightClient LightClientGenesis
+	// CounterpartyClientID is the client on the counterparty chain that tracks this chain (required).
+	// The light client is registered on the router with it.
+	CounterpartyClientID string
+	// CounterpartyMerklePrefix is the prefix of the counterparty's IBC store, defaults to DefaultMerklePrefix
+	CounterpartyMerklePrefix [][]byte
+}
+
+// DeployIBC deploys the IBC contracts (ICS26 router and ICS20 transfer behind proxies, relayer helper and the
+// ICS07 Tendermint light client, registered on the router) and a test ERC20, with the full supply minted to the faucet.
 func DeployIBC(
-	ethRPC string,
+	ctx context.Context,
+	backend Backend,
 	deployerPrivKey *ecdsa.PrivateKey,
-	faucetPubKey ecdsa.PublicKey,
+	faucetAddress ethcommon.Address,
+	opts DeployOptions,
 ) (DeployedContracts, error) {
-	genesisFilePath := "solidity-ibc-eureka/scripts/genesis.json"
-	if err := generateGenesis("groth16", genesisFilePath); err != nil {
+	if len(opts.LightClient.ClientState) == 0 {
+		return DeployedContracts{}, errors.New("a light client genesis is needed to deploy the ICS07 Tendermint light client")
+	}
+	if opts.CounterpartyClientID == "" {
+		return DeployedContracts{}, errors.New("a counterparty client id is needed to register the light client on the router")
+	}
+	if len(opts.CounterpartyMerklePrefix) == 0 {
+		opts.CounterpartyMerklePrefix = DefaultMerklePrefix
+	}
+
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return DeployedContracts{}, errors.Wrap(err, "failed to get chain id")
+	}
+
+	txOpts, err := bind.NewKeyedTransactorWithChainID(deployerPrivKey, chainID)
+	if err != nil {
+		return DeployedContracts{}, errors.Wrap(err, "failed to create transactor")
+	}
+	txOpts.Context = ctx
+
+	d := &deployer{
+		ctx:     ctx,
+		backend: backend,
+		txOpts:  txOpts,
+	}
+	deployerAddress := crypto.PubkeyToAddress(deployerPrivKey.PublicKey)
+
+	var contracts DeployedContracts
+
+	escrowLogic, err := d.deploy("Escrow", nil)
+	if err != nil {
 		return DeployedContracts{}, err
 	}
 
-	stdout, err := runDeployScript(ethRPC, deployerPrivKey, faucetPubKey)
+	ibcERC20Logic, err := d.deploy("IBCERC20", nil)
 	if err != nil {
 		return DeployedContracts{}, err
 	}
 
-	return getEthContractsFromDeployOutput(string(stdout))
-}
+	contracts.Ics26Router, err = d.deployProxy("ICS26Router", ics26router.ContractMetaData, "initialize", deployerAddress, deployerAddress)
+	if err != nil {
+		return DeployedContracts{}, err
+	}
 
-func runDeployScript(
-	ethRPC string,
-	deployerPrivKey *ecdsa.PrivateKey,
-	faucetPubKey ecdsa.PublicKey,
-) ([]byte, error) {
-	cmd := exec.Command(
-		"forge",
-		"script",
-		"solidity-ibc-eureka/scripts/E2ETestDeploy.s.sol:E2ETestDeploy",
-		"--rpc-url", ethRPC,
-		"--private-key", hex.EncodeToString(crypto.FromECDSA(deployerPrivKey)),
-		"--broadcaoast",
-		"--non-interactive",
-		"-vvvv",
-	)
+	contracts.Ics20Transfer, err = d.deployProxy("ICS20Transfer", ics20transfer.ContractMetaData, "initialize",
+		contracts.Ics26Router, escrowLogic, ibcERC20Logic, ethcommon.Address{}, opts.Permit2)
+	if err != nil {
+		return DeployedContracts{}, err
+	}
+
+	router, err := ics26router.NewContract(contracts.Ics26Router, backend)
+	if err != nil {
+		return DeployedContracts{}, errors.Wrap(err, "failed to get ics26 router contract")
+	}
+	if err := d.transact("add ics20 app to router", func() (*ethtypes.Transaction, error) {
+		return router.AddIBCApp(txOpts, ics20PortID, contracts.Ics20Transfer)
+	}); err != nil {
+		return DeployedContracts{}, err
+	}
+
+	contracts.RelayerHelper, err = d.deploy("RelayerHelper", relayerhelper.ContractMetaData, contracts.Ics26Router)
+	if err != nil {
+		return DeployedContracts{}, err
+	}
 
-	faucetAddress := crypto.PubkeyToAddress(faucetPubKey)
-	extraEnv := []string{
-		fmt.Sprintf("E2E_FAUCET_ADDRESS=%s", faucetAddress.Hex()),
+	contracts.Erc20, err = d.deployTestERC20(faucetAddress)
+	if err != nil {
+		return DeployedContracts{}, err
 	}
 
-	cmd.Env = os.Environ()
-	cmd.Env = append(cmd.Env, extraEnv...)
+	contracts.Ics07Tendermint, err = d.deployLightClient(opts.LightClient)
+	if err != nil {
+		return DeployedContracts{}, err
+	}
 
-	var stdoutBuf bytes.Buffer
+	counterpartyInfo := ics26router.IICS02ClientMsgsCounterpartyInfo{
+		ClientId:     opts.CounterpartyClientID,
+		MerklePrefix: opts.CounterpartyMerklePrefix,
+	}
+	if err := d.transact("add light client to router", func() (*ethtypes.Transaction, error) {
+		return router.AddClient(txOpts, counterpartyInfo, contracts.Ics07Tendermint)
+	}); err != nil {
+		return DeployedContracts{}, err
+	}
 
-	// Create a MultiWriter to write to both os.Stdout and the buffer
-	multiWriter := io.MultiWriter(os.Stdout, &stdoutBuf)
+	return contracts, nil
+}
 
-	// Set the command's stdout to the MultiWriter
-	cmd.Stdout = multiWriter
-	cmd.Stderr = os.Stderr
+type deployer struct {
+	ctx     context.Context
+	backend Backend
+	txOpts  *bind.TransactOpts
+}
 
-	// Run the command
-	if err := cmd.Run(); err != nil {
-		fmt.Println("Error start command", cmd.Args, err)
-		return nil, err
+// deploy deploys the contract and waits for it to be mined.
+// The metadata is the abigen binding metadata, or nil if the contract has no bindings.
+func (d *deployer) deploy(name string, metadata *bind.MetaData, params ...any) (ethcommon.Address, error) {
+	contractABI, bytecode, err := contractCode(name, metadata)
+	if err != nil {
+		return ethcommon.Address{}, err
 	}
 
-	// Get the output as byte slices
-	stdoutBytes := stdoutBuf.Bytes()
+	_, tx, _, err := bind.DeployContract(d.txOpts, *contractABI, bytecode, d.backend, params...)
+	if err != nil {
+		return ethcommon.Address{}, errors.Wrapf(err, "failed to deploy %s", name)
+	}
 
-	return stdoutBytes, nil
+	address, err := bind.WaitDeployed(d.ctx, d.backend, tx)
+	if err != nil {
+		return ethcommon.Address{}, errors.Wrapf(err, "failed to wait for %s deployment in tx %s", name, tx.Hash().String())
+	}
 
+	return address, nil
 }
 
-func getEthContractsFromDeployOutput(stdout string) (DeployedContracts, error) {
-	// Remove everything above the JSON part
-	cutOff := "== Return =="
-	cutoffIndex := strings.Index(stdout, cutOff)
-	stdout = stdout[cutoffIndex+len(cutOff):]
+// deployProxy deploys the contract implementation and an ERC1967 proxy in front of it, which calls the initializer.
+// The returned address is the proxy.
+func (d *deployer) deployProxy(name string, metadata *bind.MetaData, initializer string, initArgs ...any) (ethcommon.Address, error) {
+	contractABI, _, err := contractCode(name, metadata)
+	if err != nil {
+		return ethcommon.Address{}, err
+	}
 
-	// Extract the JSON part using regex
-	re := regexp.MustCompile(`\{.*\}`)
-	jsonPart := re.FindString(stdout)
+	initData, err := contractABI.Pack(initializer, initArgs...)
+	if err != nil {
+		return ethcommon.Address{}, errors.Wrapf(err, "failed to pack %s.%s", name, initializer)
+	}
 
-	jsonPart = strings.ReplaceAll(jsonPart, `\"`, `"`)
-	jsonPart = strings.Trim(jsonPart, `"`)
+	implementation, err := d.deploy(name, metadata)
+	if err != nil {
+		return ethcommon.Address{}, err
+	}
 
-	var embeddedContracts DeployedContracts
-	err := json.Unmarshal([]byte(jsonPart), &embeddedContracts)
+	proxy, err := d.deploy("ERC1967Proxy", nil, implementation, initData)
 	if err != nil {
-		return DeployedContracts{}, err
+		return ethcommon.Address{}, errors.Wrapf(err, "failed to deploy proxy for %s", name)
+	}
+
+	return proxy, nil
+}
+
+// deployTestERC20 deploys the test ERC20 and mints the max supply to the faucet.
+func (d *deployer) deployTestERC20(faucetAddress ethcommon.Address) (ethcommon.Address, error) {
+	const name = "TestERC20"
+
+	address, err := d.deploy(name, nil)
+	if err != nil {
+		return ethcommon.Address{}, err
+	}
+
+	contractABI, _, err := contractCode(name, nil)
+	if err != nil {
+		return ethcommon.Address{}, err
+	}
+
+	erc20 := bind.NewBoundContract(address, *contractABI, d.backend, d.backend, d.backend)
+	if err := d.transact("mint test erc20 to faucet", func() (*ethtypes.Transaction, error) {
+		return erc20.Transact(d.txOpts, "mint", faucetAddress, abi.MaxUint256)
+	}); err != nil {
+		return ethcommon.Address{}, err
+	}
+
+	return address, nil
+}
+
+// deployLightClient deploys the ICS07 Tendermint light client (and a mock SP1 verifier if no verifier is given).
+func (d *deployer) deployLightClient(genesis LightClientGenesis) (ethcommon.Address, error) {
+	verifier := genesis.Verifier
+	if verifier == (ethcommon.Address{}) {
+		var err error
+		verifier, err = d.deploy("SP1MockVerifier", nil)
+		if err != nil {
+			return ethcommon.Address{}, err
+		}
 	}
 
-	if embeddedContracts.Erc20 == "" ||
-		embeddedContracts.Ics07Tendermint == "" ||
-		embeddedContracts.Ics20Transfer == "" ||
-		embeddedContracts.Ics26Router == "" {
+	return d.deploy("SP1ICS07Tendermint", ics07tendermint.ContractMetaData,
+		genesis.UpdateClientVkey,
+		genesis.MembershipVkey,
+		genesis.UcAndMembershipVkey,
+		genesis.MisbehaviourVkey,
+		verifier,
+		genesis.ClientState,
+		genesis.ConsensusStateHash,
+		ethcommon.Address{}, // No role manager, so anyone can update the client
+	)
+}
+
+// transact sends a tx and waits for it to be mined successfully.
+func (d *deployer) transact(description string, send func() (*ethtypes.Transaction, error)) error {
+	tx, err := send()
+	if err != nil {
+		return errors.Wrapf(err, "failed to %s", description)
+	}
 
-		return DeployedContracts{}, fmt.Errorf("one or more contracts missing: %+v", embeddedContracts)
+	receipt, err := bind.WaitMined(d.ctx, d.backend, tx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to wait for tx %s to %s", tx.Hash().String(), description)
+	}
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return errors.Errorf("tx %s to %s failed", tx.Hash().String(), description)
 	}
 
-	return embeddedContracts, nil
+	return nil
 }
@@ -0,0 +1,22 @@
+package solidity
+
+import (
+	"context"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployIBCNeedsLightClient(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	// Both are checked before anything is deployed, so no backend is needed
+	_, err = DeployIBC(context.Background(), nil, key, ethcommon.Address{}, DeployOptions{CounterpartyClientID: "08-wasm-0"})
+	require.ErrorContains(t, err, "light client genesis")
+
+	_, err = DeployIBC(context.Background(), nil, key, ethcommon.Address{}, DeployOptions{LightClient: LightClientGenesis{ClientState: []byte{0x01}}})
+	require.ErrorContains(t, err, "counterparty client id")
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/gjermundgaraba/libibc/chains/ethereum/erc20"
 	"github.com/gjermundgaraba/libibc/chains/network"
 	"github.com/gjermundgaraba/libibc/ibc"
@@ -32,36 +31,30 @@ func (e *Ethereum) SendTransfer(
 		return ibc.Packet{}, errors.Errorf("invalid wallet type: %T", wallet)
 	}
 
-	ethClient, err := ethclient.Dial(e.ethRPC)
+	ethClient, err := e.getClient()
 	if err != nil {
-		return ibc.Packet{}, errors.Wrap(err, "failed to dial ethereum client")
+		return ibc.Packet{}, err
 	}
 
-	erc20Address := ethcommon.HexToAddress(denom)
-	erc20Contract, err := erc20.NewContract(erc20Address, ethClient)
-	if err != nil {
-		return ibc.Packet{}, errors.Wrap(err, "failed to get erc20 contract")
+	// Native ETH is wrapped into WETH first, since the ICS20 contract only transfers ERC20 tokens
+	var erc20Address ethcommon.Address
+	if IsNativeDenom(denom) {
+		erc20Address, err = e.wrapNative(ctx, ethereumWallet, amount)
+	} else {
+		erc20Address, err = e.resolveERC20Denom(ctx, denom)
 	}
-
-	ics20Contract, err := ics20transfer.NewContract(e.ics20Address, ethClient)
 	if err != nil {
-		return ibc.Packet{}, errors.Wrap(err, "failed to get ics20 contract")
+		return ibc.Packet{}, err
 	}
 
-	currentApproval, err := erc20Contract.Allowance(nil, ethereumWallet.address, e.ics20Address)
+	erc20Contract, err := erc20.NewContract(erc20Address, ethClient)
 	if err != nil {
-		return ibc.Packet{}, errors.Wrap(err, "failed to get current approval")
+		return ibc.Packet{}, errors.Wrap(err, "failed to get erc20 contract")
 	}
 
-	if currentApproval.Cmp(amount) < 0 {
-		if _, err := e.Transact(ctx, ethereumWallet, func(ethClient *ethclient.Client, txOpts *bind.TransactOpts) (*ethtypes.Transaction, error) {
-			return erc20Contract.Approve(txOpts, e.ics20Address, amount)
-		}); err != nil {
-			return ibc.Packet{}, errors.Wrap(err, "failed to approve transfer")
-		}
-
-		e.logger.Info("Approved transfer", zap.Uint64("amount", amount.Uint64()), zap.String("denom", denom), zap.String("to", to))
-		time.Sleep(5 * time.Second)
+	ics20Contract, err := e.ics20Contract()
+	if err != nil {
+		return ibc.Packet{}, err
 	}
 
 	timeout := uint64(time.Now().Add(6 * time.Hour).Unix())
@@ -75,11 +68,45 @@ func (e *Ethereum) SendTransfer(
 		Memo:             memo,
 	}
 
-	receipt, err := e.Transact(ctx, ethereumWallet, func(_ *ethclient.Client, txOpts *bind.TransactOpts) (*ethtypes.Transaction, error) {
-		return ics20Contract.SendTransfer(txOpts, sendTransferMsg)
-	})
-	if err != nil {
-		return ibc.Packet{}, errors.Wrapf(err, "failed to send transfer on ICS20Transfer address %s with sendTransferMsg: %+v", e.ics20Address.String(), sendTransferMsg)
+	var permit2Address ethcommon.Address
+	if e.approvalStrategy == ApprovalStrategyPermit2 {
+		permit2Address, err = ics20Contract.GetPermit2(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return ibc.Packet{}, errors.Wrap(err, "failed to get permit2 address")
+		}
+		if permit2Address == (ethcommon.Address{}) {
+			e.logger.Info("ICS20 contract has no permit2 contract, falling back to approve", zap.String("ics20_address", e.ics20Address.String()))
+		}
+	}
+
+	var receipt *ethtypes.Receipt
+	if permit2Address != (ethcommon.Address{}) {
+		if err := e.ensureAllowance(ctx, ethereumWallet, erc20Contract, permit2Address, amount, true); err != nil {
+			return ibc.Packet{}, errors.Wrap(err, "failed to approve permit2")
+		}
+
+		permit, signature, err := e.newPermit2Transfer(ethereumWallet, permit2Address, erc20Address, amount)
+		if err != nil {
+			return ibc.Packet{}, err
+		}
+
+		receipt, err = e.Transact(ctx, ethereumWallet, func(_ Client, txOpts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+			return ics20Contract.PermitSendTransfer(txOpts, sendTransferMsg, permit, signature)
+		})
+		if err != nil {
+			return ibc.Packet{}, errors.Wrapf(err, "failed to send permit2 transfer on ICS20Transfer address %s with sendTransferMsg: %+v", e.ics20Address.String(), sendTransferMsg)
+		}
+	} else {
+		if err := e.ensureAllowance(ctx, ethereumWallet, erc20Contract, e.ics20Address, amount, e.approvalStrategy == ApprovalStrategyInfinite); err != nil {
+			return ibc.Packet{}, errors.Wrap(err, "failed to approve transfer")
+		}
+
+		receipt, err = e.Transact(ctx, ethereumWallet, func(_ Client, txOpts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+			return ics20Contract.SendTransfer(txOpts, sendTransferMsg)
+		})
+		if err != nil {
+			return ibc.Packet{}, errors.Wrapf(err, "failed to send transfer on ICS20Transfer address %s with sendTransferMsg: %+v", e.ics20Address.String(), sendTransferMsg)
+		}
 	}
 
 	packets, err := e.GetPackets(ctx, receipt.TxHash.String())
@@ -90,7 +117,7 @@ func (e *Ethereum) SendTransfer(
 		return ibc.Packet{}, errors.Errorf("failed to get packet for transfer (expected 1, got %d)", len(packets))
 	}
 
-	e.logger.Info("Sent transfer", zap.String("tx_hash", receipt.TxHash.String()), zap.String("from", wallet.Address()), zap.String("to", to), zap.Uint64("amount", amount.Uint64()), zap.String("denom", denom))
+	e.logger.Info("Sent transfer", zap.String("tx_hash", receipt.TxHash.String()), zap.String("from", wallet.Address()), zap.String("to", to), zap.String("amount", amount.String()), zap.String("denom", denom))
 
 	return packets[0], nil
 }
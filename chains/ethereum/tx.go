@@ -4,12 +4,13 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"math/big"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/gjermundgaraba/libibc/chains/network"
 	"github.com/gjermundgaraba/libibc/utils"
@@ -18,115 +19,206 @@ import (
 )
 
 // SubmitTx implements network.Chain.
+// Returns an error wrapping network.ErrRelayTxTooLarge if the tx needs more gas than fits in a block.
 func (e *Ethereum) SubmitRelayTx(ctx context.Context, txBz []byte, wallet network.Wallet) (string, error) {
 	ethereumWallet, ok := wallet.(*Wallet)
 	if !ok {
 		return "", errors.Errorf("invalid wallet type: %T", wallet)
 	}
 
-	receipt, err := e.Transact(ctx, ethereumWallet, func(ethClient *ethclient.Client, txOpts *bind.TransactOpts) (*ethtypes.Transaction, error) {
-		unsignedTx := ethtypes.NewTransaction(
-			txOpts.Nonce.Uint64(),
+	gasLimit, err := e.EstimateRelayTxGas(ctx, txBz, ethereumWallet)
+	if err != nil {
+		return "", err
+	}
+
+	receipt, err := e.Transact(ctx, ethereumWallet, func(ethClient Client, txOpts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+		unsignedTx := NewTx(
+			e.actualChainID,
+			txOpts,
 			e.ics26Address,
 			new(big.Int).SetUint64(0),
-			15_000_000,
-			txOpts.GasPrice,
+			gasLimit,
 			txBz,
 		)
 
-		signedTx, err := txOpts.Signer(txOpts.From, unsignedTx)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to sign tx")
-		}
-
-		err = ethClient.SendTransaction(ctx, signedTx)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to submit tx")
-		}
-
-		return signedTx, nil
+		return sendTx(ctx, ethClient, txOpts, unsignedTx)
 	})
 	if err != nil {
 		return "", errors.Wrap(err, "failed to submit tx")
 	}
 
-	e.logger.Info("Submitted relay tx", zap.String("tx_hash", receipt.TxHash.String()))
+	e.logger.Info("Submitted relay tx", zap.String("tx_hash", receipt.TxHash.String()), zap.Uint64("gas_used", receipt.GasUsed), zap.Uint64("gas_limit", gasLimit))
 
 	return receipt.TxHash.String(), nil
 }
 
-func (e *Ethereum) Transact(ctx context.Context, wallet *Wallet, doTx func(*ethclient.Client, *bind.TransactOpts) (*ethtypes.Transaction, error)) (*ethtypes.Receipt, error) {
-	ethClient, err := ethclient.Dial(e.ethRPC)
+// EstimateRelayTxGas estimates the gas needed for the relay tx and returns the gas limit to use,
+// which is the estimate with the configured gas limit multiplier applied (but never more than the block gas limit).
+func (e *Ethereum) EstimateRelayTxGas(ctx context.Context, txBz []byte, wallet *Wallet) (uint64, error) {
+	ethClient, err := e.getClient()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to dial ethereum client")
+		return 0, err
 	}
 
-	txOpts, err := GetTransactOpts(ctx, ethClient, e.actualChainID, wallet.privateKey, e.extraGwei)
+	header, err := ethClient.HeaderByNumber(ctx, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get transact opts")
+		return 0, errors.Wrap(err, "failed to get latest header")
+	}
+
+	estimate, err := ethClient.EstimateGas(ctx, ethereum.CallMsg{
+		From: wallet.address,
+		To:   &e.ics26Address,
+		Data: txBz,
+	})
+	if err != nil {
+		if isGasLimitError(err) {
+			return 0, errors.Wrapf(network.ErrRelayTxTooLarge, "relay tx does not fit within the block gas limit of %d: %s", header.GasLimit, err)
+		}
+		if revertData, ok := ethclient.RevertErrorData(err); ok {
+			return 0, errors.Wrapf(err, "relay tx reverted during gas estimation: %s", DecodeRevert(revertData))
+		}
+		return 0, errors.Wrap(err, "failed to estimate gas for relay tx")
+	}
+
+	if estimate > header.GasLimit {
+		return 0, errors.Wrapf(network.ErrRelayTxTooLarge, "relay tx gas estimate %d exceeds the block gas limit of %d", estimate, header.GasLimit)
 	}
 
-	tx, err := doTx(ethClient, txOpts)
+	gasLimit := min(mulBig(new(big.Int).SetUint64(estimate), e.feeConfig.GasLimitMultiplier).Uint64(), header.GasLimit)
+
+	e.logger.Info("Estimated relay tx gas", zap.Uint64("gas_estimate", estimate), zap.Uint64("gas_limit", gasLimit), zap.Uint64("block_gas_limit", header.GasLimit))
+
+	return gasLimit, nil
+}
+
+// isGasLimitError returns true if the gas estimation failed because the tx needs more gas than is allowed in a block.
+func isGasLimitError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "gas required exceeds allowance") ||
+		strings.Contains(msg, "exceeds block gas limit")
+}
+
+// maxNonceRetries is how many times a transaction is retried with a fresh nonce when the nonce turns out to be taken
+const maxNonceRetries = 3
+
+// Transact sends the transaction created by doTx and waits for it to be included.
+// The nonce is allocated per wallet, so several transactions from the same wallet can be in flight concurrently.
+func (e *Ethereum) Transact(ctx context.Context, wallet *Wallet, doTx func(Client, *bind.TransactOpts) (*ethtypes.Transaction, error)) (*ethtypes.Receipt, error) {
+	ethClient, err := e.getClient()
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to do transaction with txOpts: %+v, using extraGwei: %d (tx, if any): %+v", txOpts, e.extraGwei, tx)
+		return nil, err
 	}
 
-	receipt, err := WaitForReceipt(ctx, ethClient, tx.Hash())
+	txOpts, err := newTransactOpts(ctx, ethClient, e.actualChainID, wallet.privateKey, e.feeConfig)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get receipt for tx %s", tx.Hash().String())
+		return nil, errors.Wrap(err, "failed to get transact opts")
+	}
+
+	// Keep the last signed tx, so that if the node says it already has it, we can wait for it instead of sending it again
+	var signedTx *ethtypes.Transaction
+	signer := txOpts.Signer
+	txOpts.Signer = func(address ethcommon.Address, unsignedTx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+		signed, err := signer(address, unsignedTx)
+		if err == nil {
+			signedTx = signed
+		}
+		return signed, err
+	}
+
+	var tx *ethtypes.Transaction
+	for attempt := 0; ; attempt++ {
+		nonce, err := e.nonces.Next(ctx, ethClient, wallet.address)
+		if err != nil {
+			return nil, err
+		}
+		txOpts.Nonce = new(big.Int).SetUint64(nonce)
+
+		signedTx = nil
+		tx, err = doTx(ethClient, txOpts)
+		if err == nil {
+			break
+		}
+
+		if isAlreadyKnownError(err) && signedTx != nil {
+			e.logger.Info("Transaction already known by the node, waiting for it", zap.String("tx_hash", signedTx.Hash().String()), zap.Uint64("nonce", nonce))
+			tx = signedTx
+			break
+		}
+
+		if !isNonceError(err) || attempt >= maxNonceRetries {
+			// Since the tx was never accepted, the nonce can be used by the next one
+			e.nonces.Release(wallet.address, nonce)
+			return nil, errors.Wrapf(err, "failed to do transaction with txOpts: %+v, using fee config: %+v (tx, if any): %+v", txOpts, e.feeConfig, tx)
+		}
+
+		e.logger.Info("Nonce already used, resyncing and retrying", zap.String("address", wallet.address.String()), zap.Uint64("nonce", nonce), zap.Error(err))
+		if err := e.nonces.Resync(ctx, ethClient, wallet.address); err != nil {
+			return nil, err
+		}
+	}
+
+	// The tx might be replaced with bumped fees while waiting, so the receipt can be for a different tx hash than the one we sent
+	receipt, latestTx, err := e.waitForMined(ctx, ethClient, txOpts, tx)
+	if err != nil {
+		// If the tx was dropped, its nonce is now a gap that the next transaction needs to fill
+		if _, _, txErr := ethClient.TransactionByHash(ctx, latestTx.Hash()); errors.Is(txErr, ethereum.NotFound) {
+			e.logger.Info("Transaction was dropped, resyncing nonce", zap.String("tx_hash", latestTx.Hash().String()), zap.Uint64("nonce", latestTx.Nonce()))
+			if resyncErr := e.nonces.Resync(ctx, ethClient, wallet.address); resyncErr != nil {
+				e.logger.Error("Failed to resync nonce", zap.String("address", wallet.address.String()), zap.Error(resyncErr))
+			}
+		}
+
+		return nil, err
 	}
 
 	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
-		return nil, errors.Errorf("tx failed for %s, with receipt: %v", tx.Hash().String(), receipt)
+		// Replacements only change the fees, so the latest tx can be used to replay whichever one landed
+		reason, err := RevertReason(ctx, ethClient, e.actualChainID, latestTx, receipt.BlockNumber)
+		if err != nil {
+			e.logger.Debug("Failed to get revert reason", zap.String("tx_hash", receipt.TxHash.String()), zap.Error(err))
+			reason = "unknown"
+		}
+
+		return nil, errors.Errorf("tx failed for %s with revert reason: %s, receipt: %v", receipt.TxHash.String(), reason, receipt)
 	}
 
 	return receipt, nil
 }
 
-func GetTransactOpts(ctx context.Context, ethClient *ethclient.Client, chainID *big.Int, key *ecdsa.PrivateKey, extraGwei int64) (*bind.TransactOpts, error) {
-	fromAddress := crypto.PubkeyToAddress(key.PublicKey)
-
-	txOpts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+// GetTransactOpts creates transact opts for the key with fees set according to the fee config and the pending nonce of the key's address.
+func GetTransactOpts(ctx context.Context, ethClient Client, chainID *big.Int, key *ecdsa.PrivateKey, feeConfig FeeConfig) (*bind.TransactOpts, error) {
+	txOpts, err := newTransactOpts(ctx, ethClient, chainID, key, feeConfig)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create transactor")
+		return nil, err
 	}
 
-	// Get the suggested gas price from the client.
-	suggestedGasPrice, err := ethClient.SuggestGasPrice(ctx)
+	nonce, err := ethClient.PendingNonceAt(ctx, txOpts.From)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get suggested gas price")
+		return nil, errors.Wrapf(err, "failed to get pending nonce for %s", txOpts.From.String())
 	}
+	txOpts.Nonce = new(big.Int).SetUint64(nonce)
 
-	txOpts.GasPrice = suggestedGasPrice
-	// txOpts.GasPrice = new(big.Int).Add(suggestedGasPrice, big.NewInt(extraGwei*1000000000)) // Add extra Gwei
+	return txOpts, nil
+}
 
-	nonce, err := ethClient.PendingNonceAt(context.Background(), fromAddress)
+// newTransactOpts creates transact opts with the fees set, but leaves the nonce to the caller.
+func newTransactOpts(ctx context.Context, ethClient Client, chainID *big.Int, key *ecdsa.PrivateKey, feeConfig FeeConfig) (*bind.TransactOpts, error) {
+	txOpts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
 	if err != nil {
-		nonce = 0
-	}
-	txOpts.Nonce = big.NewInt(int64(nonce))
-
-	// header, err := ethClient.HeaderByNumber(ctx, nil)
-	// if err != nil {
-	// 	panic(err)
-	// }
-	//
-	// // For EIP-1559 transactions: double the gas tip and fee cap.
-	// tipCap, err := ethClient.SuggestGasTipCap(ctx)
-	// if err != nil {
-	// 	panic(err)
-	// }
-	// txOpts.GasTipCap = new(big.Int).Mul(tipCap, big.NewInt(5))
-	// // Compute the gas fee cap by doubling the sum of header.BaseFee and the original tipCap.
-	// txOpts.GasFeeCap = new(big.Int).Mul(new(big.Int).Add(header.BaseFee, tipCap), big.NewInt(5))
+		return nil, errors.Wrap(err, "failed to create transactor")
+	}
+
+	if err := SetTxFees(ctx, ethClient, txOpts, feeConfig); err != nil {
+		return nil, errors.Wrap(err, "failed to set tx fees")
+	}
 
 	return txOpts, nil
 }
 
-func WaitForReceipt(ctx context.Context, ethClient *ethclient.Client, hash ethcommon.Hash) (*ethtypes.Receipt, error) {
+func WaitForReceipt(ctx context.Context, ethClient Client, hash ethcommon.Hash) (*ethtypes.Receipt, error) {
 
 	var receipt *ethtypes.Receipt
-	if err := utils.WaitForCondition(time.Second*120, time.Second, func() (bool, error) {
+	if err := utils.WaitForConditionWithContext(ctx, receiptTimeout, time.Second, func() (bool, error) {
 		var err error
 		receipt, err = ethClient.TransactionReceipt(ctx, hash)
 		if err != nil {
@@ -0,0 +1,126 @@
+package network
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// TokenMetadata describes how amounts of a denom are displayed.
+// Amounts on chain are always in base units, and one display unit is 10^Decimals base units.
+type TokenMetadata struct {
+	Denom    string
+	Symbol   string
+	Decimals uint8
+}
+
+// DisplaySymbol returns the symbol, or the denom if the token has no symbol.
+func (m TokenMetadata) DisplaySymbol() string {
+	if m.Symbol != "" {
+		return m.Symbol
+	}
+
+	return m.Denom
+}
+
+// IsBaseUnitAmount returns true if the amount is a plain integer without decimals or symbol,
+// in which case it is taken as base units and no token metadata is needed to parse it.
+func IsBaseUnitAmount(amount string) bool {
+	_, ok := new(big.Int).SetString(amount, 10)
+	return ok && !strings.HasPrefix(amount, "-")
+}
+
+// ParseAmount parses a human-readable amount into base units.
+// A plain integer (e.g. 1000), or an integer followed by the base denom (e.g. 1000uatom), is taken as base units.
+// An amount with decimals (e.g. 1.5) or followed by the token symbol (e.g. 0.01WETH or 2 ETH) is taken as display units
+// and scaled by the token decimals. The symbol is matched case-insensitively.
+func ParseAmount(amount string, metadata TokenMetadata) (*big.Int, error) {
+	amount = strings.TrimSpace(amount)
+	if IsBaseUnitAmount(amount) {
+		baseUnits, _ := new(big.Int).SetString(amount, 10)
+		return baseUnits, nil
+	}
+
+	number, symbol := splitAmountSymbol(amount)
+	whole, fraction, hasFraction := strings.Cut(number, ".")
+	if whole == "" && fraction == "" {
+		return nil, errors.Errorf("invalid amount %s: missing number", amount)
+	}
+	if !isDigits(whole) || !isDigits(fraction) {
+		return nil, errors.Errorf("invalid amount %s: must be a positive number, optionally followed by the token symbol", amount)
+	}
+
+	var decimals int
+	switch {
+	case symbol == "" || (metadata.Symbol != "" && strings.EqualFold(symbol, metadata.Symbol)):
+		decimals = int(metadata.Decimals)
+	case strings.EqualFold(symbol, metadata.Denom):
+		// The base denom, so the amount is in base units
+		if hasFraction {
+			return nil, errors.Errorf("invalid amount %s: amounts in base units (%s) can't have decimals", amount, metadata.Denom)
+		}
+	default:
+		return nil, errors.Errorf("invalid amount %s: symbol %s does not match %s", amount, symbol, metadata.DisplaySymbol())
+	}
+
+	if len(fraction) > decimals {
+		return nil, errors.Errorf("invalid amount %s: %s only has %d decimals", amount, metadata.DisplaySymbol(), decimals)
+	}
+
+	digits := whole + fraction + strings.Repeat("0", decimals-len(fraction))
+	baseUnits, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid amount %s", amount)
+	}
+
+	return baseUnits, nil
+}
+
+// FormatAmount formats an amount in base units as display units with the token symbol (e.g. 1.5 WETH).
+func FormatAmount(amount *big.Int, metadata TokenMetadata) string {
+	if amount == nil {
+		amount = new(big.Int)
+	}
+
+	digits := new(big.Int).Abs(amount).String()
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+	}
+
+	decimals := int(metadata.Decimals)
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	whole := digits[:len(digits)-decimals]
+	fraction := strings.TrimRight(digits[len(digits)-decimals:], "0")
+	if fraction != "" {
+		whole += "." + fraction
+	}
+
+	return sign + whole + " " + metadata.DisplaySymbol()
+}
+
+// splitAmountSymbol splits an amount like 0.01WETH or "2 ETH" into the number and the symbol.
+func splitAmountSymbol(amount string) (string, string) {
+	i := strings.IndexFunc(amount, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		return amount, ""
+	}
+
+	return amount[:i], strings.TrimSpace(amount[i:])
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
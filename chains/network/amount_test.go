@@ -0,0 +1,64 @@
+package network
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	weth := TokenMetadata{Denom: "0x3aF134307D5Ee90faa2ba9Cdba14ba66414CF1A7", Symbol: "WETH", Decimals: 18}
+	atom := TokenMetadata{Denom: "uatom", Symbol: "ATOM", Decimals: 6}
+	eth := TokenMetadata{Denom: "eth", Symbol: "ETH", Decimals: 18}
+
+	tests := []struct {
+		amount   string
+		metadata TokenMetadata
+		expected string
+		err      bool
+	}{
+		{amount: "1000", metadata: weth, expected: "1000"},
+		{amount: "1.5WETH", metadata: weth, expected: "1500000000000000000"},
+		{amount: "0.01WETH", metadata: weth, expected: "10000000000000000"},
+		{amount: "0.01 weth", metadata: weth, expected: "10000000000000000"},
+		{amount: ".5ATOM", metadata: atom, expected: "500000"},
+		{amount: "2ATOM", metadata: atom, expected: "2000000"},
+		{amount: "3uatom", metadata: atom, expected: "3"},
+		{amount: "3 UATOM", metadata: atom, expected: "3"},
+		{amount: "2ETH", metadata: eth, expected: "2000000000000000000"},
+		{amount: "5uatom", metadata: TokenMetadata{Denom: "uatom"}, expected: "5"},
+		{amount: "1.5", metadata: weth, expected: "1500000000000000000"},
+		{amount: "1.0", metadata: atom, expected: "1000000"},
+		{amount: "0.01", metadata: weth, expected: "10000000000000000"},
+		{amount: "0.0000001", metadata: atom, err: true},
+		{amount: "1.5uatom", metadata: atom, err: true},
+		{amount: "0.0000001ATOM", metadata: atom, err: true},
+		{amount: "1.5ETH", metadata: weth, err: true},
+		{amount: "1.2.3WETH", metadata: weth, err: true},
+		{amount: "WETH", metadata: weth, err: true},
+		{amount: "-1.5", metadata: weth, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			amount, err := ParseAmount(tt.amount, tt.metadata)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, amount.String())
+		})
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	weth := TokenMetadata{Denom: "0x3aF134307D5Ee90faa2ba9Cdba14ba66414CF1A7", Symbol: "WETH", Decimals: 18}
+
+	require.Equal(t, "1.5 WETH", FormatAmount(big.NewInt(1_500_000_000_000_000_000), weth))
+	require.Equal(t, "0.00000000000000001 WETH", FormatAmount(big.NewInt(10), weth))
+	require.Equal(t, "0 WETH", FormatAmount(big.NewInt(0), weth))
+	require.Equal(t, "2 WETH", FormatAmount(big.NewInt(2_000_000_000_000_000_000), weth))
+	require.Equal(t, "100 uatom", FormatAmount(big.NewInt(100), TokenMetadata{Denom: "uatom"}))
+}
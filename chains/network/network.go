@@ -2,6 +2,8 @@ package network
 
 import (
 	"context"
+	stderrors "errors"
+	"io"
 	"maps"
 	"math/big"
 	"time"
@@ -11,6 +13,10 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrRelayTxTooLarge is returned from SubmitRelayTx when the relay tx needs more gas than fits in a block,
+// meaning the packets should be relayed in smaller batches.
+var ErrRelayTxTooLarge = errors.New("relay tx too large")
+
 type Network struct {
 	Relayer     Relayer
 	logger      *zap.Logger
@@ -41,6 +47,29 @@ type Chain interface {
 	SendTransfer(ctx context.Context, clientID string, wallet Wallet, amount *big.Int, denom string, to string, memo string) (ibc.Packet, error)
 	Send(ctx context.Context, wallet Wallet, amount *big.Int, denom string, toAddress string) (string, error)
 	GetBalance(ctx context.Context, address string, denom string) (*big.Int, error)
+	GetAllBalances(ctx context.Context, address string) (map[string]*big.Int, error)
+	GetTokenMetadata(ctx context.Context, denom string) (TokenMetadata, error)
+
+	// Close releases the chain's connections. The chain reconnects if it is used again.
+	Close() error
+}
+
+// BatchTransferer is implemented by chains that can pack several transfers into a single tx.
+type BatchTransferer interface {
+	SendTransfers(ctx context.Context, clientID string, wallet Wallet, transfers []Transfer) ([]ibc.Packet, error)
+}
+
+// TxCanceller is implemented by chains where a pending tx can be cancelled by replacing it.
+type TxCanceller interface {
+	CancelTx(ctx context.Context, wallet Wallet, txHash string) (string, error)
+}
+
+// Transfer is a single transfer in a batch of transfers.
+type Transfer struct {
+	Amount *big.Int
+	Denom  string
+	To     string
+	Memo   string
 }
 
 type Wallet interface {
@@ -70,6 +99,24 @@ func BuildNetwork(logger *zap.Logger, chains []Chain, relayer Relayer) (*Network
 	return network, nil
 }
 
+// Close closes all the chains, and the relayer if it holds connections (implements io.Closer).
+func (n *Network) Close() error {
+	var errs []error
+	for chainID, chain := range n.chains {
+		if err := chain.Close(); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to close chain %s", chainID))
+		}
+	}
+
+	if closer, ok := n.Relayer.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to close relayer"))
+		}
+	}
+
+	return stderrors.Join(errs...)
+}
+
 func (n *Network) GetChain(chainID string) (Chain, error) {
 	chain, ok := n.chains[chainID]
 	if !ok || chain == nil {
@@ -115,9 +115,8 @@ func (rq *RelayerQueue) relay(packets ...ibc.Packet) error {
 
 		rq.logger.Info("Relaying packets", zap.Strings("tx_ids", txIDs), zap.String("source_chain", rq.sourceChain.GetChainID()), zap.String("destination_chain", rq.destinationChain.GetChainID()), zap.String("destination_client", destClient), zap.Any("relayer-wallet", rq.relayerWallet.Address()))
 
-		_, err := rq.relayer.Relay(ctx, rq.sourceChain, rq.destinationChain, srcClient, destClient, rq.relayerWallet, txIDs)
-		if err != nil {
-			return errors.Wrapf(err, "failed to relay packets: %v", txIDs)
+		if err := rq.relayTxs(ctx, srcClient, destClient, txIDs); err != nil {
+			return err
 		}
 
 		rq.logger.Info("Finished relaying packets", zap.Strings("tx_ids", txIDs), zap.String("source_chain", rq.sourceChain.GetChainID()), zap.String("destination_chain", rq.destinationChain.GetChainID()), zap.String("destination_client", destClient), zap.Any("relayer-address", rq.relayerWallet.Address()))
@@ -175,3 +174,22 @@ func (rq *RelayerQueue) relay(packets ...ibc.Packet) error {
 
 	return nil
 }
+
+// relayTxs relays the packets in the txs, splitting the batch in half (recursively) if the relay tx is too large.
+func (rq *RelayerQueue) relayTxs(ctx context.Context, srcClient string, destClient string, txIDs []string) error {
+	_, err := rq.relayer.Relay(ctx, rq.sourceChain, rq.destinationChain, srcClient, destClient, rq.relayerWallet, txIDs)
+	if errors.Is(err, ErrRelayTxTooLarge) && len(txIDs) > 1 {
+		half := len(txIDs) / 2
+		rq.logger.Info("Relay tx too large, splitting batch", zap.Int("num_txs", len(txIDs)), zap.Strings("first_half", txIDs[:half]), zap.Strings("second_half", txIDs[half:]))
+
+		if err := rq.relayTxs(ctx, srcClient, destClient, txIDs[:half]); err != nil {
+			return err
+		}
+		return rq.relayTxs(ctx, srcClient, destClient, txIDs[half:])
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to relay packets: %v", txIDs)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/gjermundgaraba/libibc/chains/network"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// parseAmount parses an amount argument for the denom on the chain.
+// Plain integers (or integers followed by the base denom, e.g. 1000uatom) are base units, while amounts with decimals
+// or followed by the token symbol (e.g. 1.5 or 0.01WETH) are scaled using the token metadata.
+func parseAmount(ctx context.Context, chain network.Chain, amountStr string, denom string) (*big.Int, error) {
+	metadata := network.TokenMetadata{Denom: denom}
+	if !network.IsBaseUnitAmount(amountStr) {
+		var err error
+		metadata, err = chain.GetTokenMetadata(ctx, denom)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get token metadata for %s to parse amount %s", denom, amountStr)
+		}
+	}
+
+	amount, err := network.ParseAmount(amountStr, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return amount, nil
+}
+
+// displayMetadata returns the token metadata used to print amounts of the denom on the chain,
+// falling back to base units if it can't be queried.
+func displayMetadata(ctx context.Context, chain network.Chain, denom string) network.TokenMetadata {
+	metadata, err := chain.GetTokenMetadata(ctx, denom)
+	if err != nil {
+		logger.Debug("Failed to get token metadata, using base units", zap.String("denom", denom), zap.Error(err))
+		return network.TokenMetadata{Denom: denom}
+	}
+
+	return metadata
+}
@@ -3,13 +3,17 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/gjermundgaraba/libibc/chains/network"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
 
 func balanceCmd() *cobra.Command {
-	var walletID string
+	var (
+		walletID string
+		raw      bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "balance [chain-id] [denom] [address]",
@@ -17,7 +21,9 @@ func balanceCmd() *cobra.Command {
 		Long: `Query the balance for an address on a specific chain and denomination.
 If address is not provided, it will use the address from the specified wallet.
 For Ethereum chain, use "eth" as the denom for native ETH balance, 
-or ERC20 contract address for token balances.`,
+or ERC20 contract address for token balances.
+Tokens received over IBC on Ethereum can also be queried by their full denom path (e.g. transfer/08-wasm-274/uatom).
+The balance is printed with the token decimals and symbol (e.g. 1.5 WETH), use --raw to print it in base units.`,
 		Args: cobra.RangeArgs(2, 3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
@@ -37,12 +43,13 @@ or ERC20 contract address for token balances.`,
 				return errors.New("either wallet-id flag or address argument must be provided")
 			}
 
-			network, err := cfg.ToNetwork(ctx, logger, extraGwei)
+			ibcNetwork, err := cfg.ToNetwork(ctx, logger, extraGwei)
 			if err != nil {
 				return errors.Wrap(err, "failed to build network")
 			}
+			defer ibcNetwork.Close()
 
-			chain, err := network.GetChain(chainID)
+			chain, err := ibcNetwork.GetChain(chainID)
 			if err != nil {
 				return errors.Wrapf(err, "failed to get chain %s", chainID)
 			}
@@ -68,13 +75,18 @@ or ERC20 contract address for token balances.`,
 				zap.String("balance", balance.String()))
 
 			// Print balance to stdout for easy consumption by scripts
-			fmt.Println(balance.String())
+			if raw {
+				fmt.Println(balance.String())
+			} else {
+				fmt.Println(network.FormatAmount(balance, displayMetadata(ctx, chain, denom)))
+			}
 
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&walletID, "wallet-id", "", "Optional wallet ID to query balance for")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the balance in base units without decimals or symbol")
 
 	return cmd
 }
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gjermundgaraba/libibc/chains/network"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func balancesCmd() *cobra.Command {
+	var (
+		walletID string
+		raw      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "balances [chain-id] [address]",
+		Short: "Query all balances for an address on a specific chain",
+		Long: `Query the balances of all denominations for an address on a specific chain.
+If address is not provided, it will use the address from the specified wallet.
+For Ethereum chains, only the native ETH balance is returned.
+The balances are printed with the token decimals and symbol (followed by the denom if it differs from the symbol),
+use --raw to print them in base units.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			chainID := args[0]
+
+			logWriter.AddExtraLogger(func(entry string) {
+				fmt.Println(entry)
+			})
+
+			var address string
+			if len(args) == 2 {
+				address = args[1]
+			} else if walletID == "" {
+				return errors.New("either wallet-id flag or address argument must be provided")
+			}
+
+			ibcNetwork, err := cfg.ToNetwork(ctx, logger, extraGwei)
+			if err != nil {
+				return errors.Wrap(err, "failed to build network")
+			}
+			defer ibcNetwork.Close()
+
+			chain, err := ibcNetwork.GetChain(chainID)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get chain %s", chainID)
+			}
+
+			// If using wallet, get the address
+			if address == "" {
+				wallet, err := chain.GetWallet(walletID)
+				if err != nil {
+					return errors.Wrapf(err, "failed to get wallet %s", walletID)
+				}
+				address = wallet.Address()
+			}
+
+			balances, err := chain.GetAllBalances(ctx, address)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get balances for address %s", address)
+			}
+
+			logger.Info("Balances retrieved",
+				zap.String("chain_id", chainID),
+				zap.String("address", address),
+				zap.Int("num_denoms", len(balances)))
+
+			// Print balances to stdout for easy consumption by scripts
+			denoms := make([]string, 0, len(balances))
+			for denom := range balances {
+				denoms = append(denoms, denom)
+			}
+			slices.Sort(denoms)
+			for _, denom := range denoms {
+				if raw {
+					fmt.Printf("%s %s\n", balances[denom].String(), denom)
+					continue
+				}
+
+				metadata := displayMetadata(ctx, chain, denom)
+				if metadata.DisplaySymbol() == denom {
+					fmt.Println(network.FormatAmount(balances[denom], metadata))
+				} else {
+					fmt.Printf("%s (%s)\n", network.FormatAmount(balances[denom], metadata), denom)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&walletID, "wallet-id", "", "Optional wallet ID to query balances for")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the balances in base units without decimals or symbol")
+
+	return cmd
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/gjermundgaraba/libibc/chains/network"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func cancelTxCmd() *cobra.Command {
+	var walletID string
+
+	cmd := &cobra.Command{
+		Use:   "cancel-tx [chain-id] [tx-hash]",
+		Short: "Cancel a pending transaction",
+		Long: `Cancel a pending transaction by replacing it with a 0-value transfer to the sender, using the same nonce and higher fees.
+The wallet must be the one that sent the transaction. Only supported on Ethereum chains.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			chainID := args[0]
+			txHash := args[1]
+
+			logWriter.AddExtraLogger(func(entry string) {
+				fmt.Println(entry)
+			})
+
+			if walletID == "" {
+				return errors.New("wallet-id flag must be provided")
+			}
+
+			ibcNetwork, err := cfg.ToNetwork(ctx, logger, extraGwei)
+			if err != nil {
+				return errors.Wrap(err, "failed to build network")
+			}
+			defer ibcNetwork.Close()
+
+			chain, err := ibcNetwork.GetChain(chainID)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get chain %s", chainID)
+			}
+
+			canceller, ok := chain.(network.TxCanceller)
+			if !ok {
+				return errors.Errorf("chain %s does not support cancelling transactions", chainID)
+			}
+
+			wallet, err := chain.GetWallet(walletID)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get wallet %s", walletID)
+			}
+
+			cancelTxHash, err := canceller.CancelTx(ctx, wallet, txHash)
+			if err != nil {
+				return errors.Wrapf(err, "failed to cancel tx %s", txHash)
+			}
+
+			logger.Info("Transaction cancelled",
+				zap.String("chain_id", chainID),
+				zap.String("tx_hash", txHash),
+				zap.String("cancel_tx_hash", cancelTxHash))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&walletID, "wallet-id", "", "Wallet ID that sent the transaction")
+
+	return cmd
+}
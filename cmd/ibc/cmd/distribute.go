@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/gjermundgaraba/libibc/chains/network"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -16,7 +17,8 @@ func distributeCmd() *cobra.Command {
 		Long: `Distribute tokens from a sender wallet to all other wallets on a chain to ensure they have at least the minimum amount.
 - Each recipient's balance is checked and tokens are only sent if their balance is below the minimum amount.
 - If a recipient's balance is already equal to or higher than the minimum amount, no tokens are sent.
-- The denom argument specifies which token to distribute (e.g., 'uatom' for Cosmos, 'eth' for Ethereum, or ERC20 contract address).`,
+- The denom argument specifies which token to distribute (e.g., 'uatom' for Cosmos, 'eth' for Ethereum, or ERC20 contract address).
+- The minimum amount is either an integer in base units (e.g. 500000 or 500000uatom) or an amount with decimals or followed by the token symbol (e.g. 0.5 or 0.5ETH).`,
 		Args: cobra.ExactArgs(4),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
@@ -28,21 +30,23 @@ func distributeCmd() *cobra.Command {
 				cmd.Println(entry)
 			})
 
-			minimumAmount, success := new(big.Int).SetString(args[3], 10)
-			if !success {
-				return errors.New("invalid minimum amount, must be a valid integer")
-			}
-
-			network, err := cfg.ToNetwork(ctx, logger, extraGwei)
+			ibcNetwork, err := cfg.ToNetwork(ctx, logger, extraGwei)
 			if err != nil {
 				return errors.Wrap(err, "failed to build network")
 			}
+			defer ibcNetwork.Close()
 
-			chain, err := network.GetChain(chainID)
+			chain, err := ibcNetwork.GetChain(chainID)
 			if err != nil {
 				return errors.Wrapf(err, "failed to get chain %s", chainID)
 			}
 
+			minimumAmount, err := parseAmount(ctx, chain, args[3], denom)
+			if err != nil {
+				return errors.Wrap(err, "invalid minimum amount")
+			}
+			metadata := displayMetadata(ctx, chain, denom)
+
 			senderWallet, err := chain.GetWallet(senderWalletID)
 			if err != nil {
 				return errors.Wrapf(err, "failed to get sender wallet %s", senderWalletID)
@@ -57,7 +61,7 @@ func distributeCmd() *cobra.Command {
 				zap.String("chain", chainID),
 				zap.String("sender", senderWalletID),
 				zap.Int("num_recipients", len(wallets)-1),
-				zap.String("minimum_amount", minimumAmount.String()),
+				zap.String("minimum_amount", network.FormatAmount(minimumAmount, metadata)),
 				zap.String("denom", denom))
 
 			for _, wallet := range wallets {
@@ -74,16 +78,16 @@ func distributeCmd() *cobra.Command {
 				logger.Info("Checking current balance",
 					zap.String("wallet_id", wallet.ID()),
 					zap.String("address", wallet.Address()),
-					zap.String("current_balance", currentBalance.String()),
-					zap.String("minimum_amount", minimumAmount.String()))
+					zap.String("current_balance", network.FormatAmount(currentBalance, metadata)),
+					zap.String("minimum_amount", network.FormatAmount(minimumAmount, metadata)))
 
 				// If current balance is already >= minimumAmount, skip this wallet
 				if currentBalance.Cmp(minimumAmount) >= 0 {
 					logger.Info("Skipping wallet as balance already meets or exceeds minimum amount",
 						zap.String("wallet_id", wallet.ID()),
 						zap.String("address", wallet.Address()),
-						zap.String("current_balance", currentBalance.String()),
-						zap.String("minimum_amount", minimumAmount.String()))
+						zap.String("current_balance", network.FormatAmount(currentBalance, metadata)),
+						zap.String("minimum_amount", network.FormatAmount(minimumAmount, metadata)))
 					continue
 				}
 
@@ -92,9 +96,9 @@ func distributeCmd() *cobra.Command {
 
 				logger.Info("Sending additional tokens to reach minimum amount",
 					zap.String("wallet_id", wallet.ID()),
-					zap.String("current_balance", currentBalance.String()),
-					zap.String("amount_to_send", amountToSend.String()),
-					zap.String("target_minimum", minimumAmount.String()))
+					zap.String("current_balance", network.FormatAmount(currentBalance, metadata)),
+					zap.String("amount_to_send", network.FormatAmount(amountToSend, metadata)),
+					zap.String("target_minimum", network.FormatAmount(minimumAmount, metadata)))
 
 				// Send the calculated amount
 				txHash, err := chain.Send(ctx, senderWallet, amountToSend, denom, wallet.Address())
@@ -109,7 +113,7 @@ func distributeCmd() *cobra.Command {
 					zap.String("from", senderWalletID),
 					zap.String("to", wallet.ID()),
 					zap.String("to_address", wallet.Address()),
-					zap.String("amount", amountToSend.String()),
+					zap.String("amount", network.FormatAmount(amountToSend, metadata)),
 					zap.String("denom", denom),
 					zap.String("tx_hash", txHash))
 			}
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"math/big"
-
 	"github.com/gjermundgaraba/libibc/cmd/ibc/config"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -44,6 +42,7 @@ func generateWalletCmd() *cobra.Command {
 			if err != nil {
 				return errors.Wrap(err, "failed to build network")
 			}
+			defer network.Close()
 
 			chain, err := network.GetChain(chainID)
 			if err != nil {
@@ -94,9 +93,9 @@ func generateWalletCmd() *cobra.Command {
 			}
 
 			if fundFromWalletId != "" && fundAmount != "" {
-				amount, success := new(big.Int).SetString(fundAmount, 10)
-				if !success {
-					return errors.New("invalid fund amount, must be a valid integer")
+				amount, err := parseAmount(ctx, chain, fundAmount, denom)
+				if err != nil {
+					return errors.Wrap(err, "invalid fund amount")
 				}
 
 				fundFromWallet, err := chain.GetWallet(fundFromWalletId)
@@ -127,7 +126,7 @@ func generateWalletCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&fundFromWalletId, "fund-from-wallet", "", "Optional wallet ID to fund the new wallet from")
-	cmd.Flags().StringVar(&fundAmount, "fund-amount", "", "Optional amount to fund the new wallet with")
+	cmd.Flags().StringVar(&fundAmount, "fund-amount", "", "Optional amount to fund the new wallet with, as an integer in base units, or with decimals or the token symbol (e.g. 0.1 or 0.1ETH)")
 	cmd.Flags().StringVar(&denom, "denom", "", "Token denomination for funding (e.g., 'uatom' for Cosmos, 'eth' for Ethereum, or ERC20 contract address)")
 
 	return cmd
@@ -24,6 +24,7 @@ func relayCmd() *cobra.Command {
 			if err != nil {
 				return errors.Wrap(err, "failed to build network")
 			}
+			defer network.Close()
 
 			fromChain, err := network.GetChain(args[0])
 			if err != nil {
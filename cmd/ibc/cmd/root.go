@@ -37,7 +37,7 @@ func NewRootCmd() *cobra.Command {
 
 	rootCmd.PersistentFlags().StringVar(&configPath, "config", "config.toml", "config file path")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "log level (debug, info, warn, error)")
-	rootCmd.PersistentFlags().Int64Var(&extraGwei, "extra-gwei", 0, "extra gwei to add to gas price")
+	rootCmd.PersistentFlags().Int64Var(&extraGwei, "extra-gwei", 0, "extra gwei to add to the priority fee (or gas price with legacy pricing) on ethereum chains")
 
 	rootCmd.AddCommand(
 		traceCmd(),
@@ -46,7 +46,9 @@ func NewRootCmd() *cobra.Command {
 		distributeCmd(),
 		generateWalletCmd(),
 		balanceCmd(),
+		balancesCmd(),
 		transferCmd(),
+		cancelTxCmd(),
 	)
 
 	return rootCmd
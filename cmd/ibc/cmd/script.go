@@ -19,7 +19,8 @@ func scriptCmd() *cobra.Command {
 	var (
 		maxWallets          int
 		numPacketsPerWallet int
-		transferAmount      int
+		msgsPerTx           int
+		transferAmount      string
 
 		chainAId              string
 		chainAClientId        string
@@ -45,12 +46,16 @@ func scriptCmd() *cobra.Command {
 			if err != nil {
 				return errors.Wrap(err, "failed to build network")
 			}
+			defer network.Close()
 
-			transferAmountBig := big.NewInt(int64(transferAmount))
 			chainA, err := network.GetChain(chainAId)
 			if err != nil {
 				return errors.Wrapf(err, "failed to get chain %s", chainAId)
 			}
+			transferAmountBig, err := parseAmount(ctx, chainA, transferAmount, chainADenom)
+			if err != nil {
+				return errors.Wrapf(err, "invalid transfer amount %s", transferAmount)
+			}
 			chainB, err := network.GetChain(chainBId)
 			if err != nil {
 				return errors.Wrapf(err, "failed to get chain %s", chainBId)
@@ -109,6 +114,7 @@ func scriptCmd() *cobra.Command {
 						chainBRelayerWallet,
 						transferAmountBig,
 						numPacketsPerWallet,
+						msgsPerTx,
 						selfRelay,
 					)
 				})
@@ -128,6 +134,7 @@ func scriptCmd() *cobra.Command {
 						chainARelayerWallet,
 						transferAmountBig,
 						numPacketsPerWallet,
+						msgsPerTx,
 						selfRelay,
 					)
 				})
@@ -153,7 +160,8 @@ func scriptCmd() *cobra.Command {
 
 	cmd.Flags().IntVar(&maxWallets, "max-wallets", 5, "Maximum number of wallets to use")
 	cmd.Flags().IntVar(&numPacketsPerWallet, "packets-per-wallet", 5, "Number of packets to send per wallet")
-	cmd.Flags().IntVar(&transferAmount, "transfer-amount", 100, "Amount to transfer")
+	cmd.Flags().IntVar(&msgsPerTx, "msgs-per-tx", 1, "Number of transfer messages to pack into a single tx (only on chains that support batched transfers)")
+	cmd.Flags().StringVar(&transferAmount, "transfer-amount", "100", "Amount to transfer, as an integer in base units or with decimals or the chain A token symbol (e.g. 0.01WETH)")
 	cmd.Flags().StringVar(&chainAId, "chain-a-id", "11155111", "Chain A ID")
 	cmd.Flags().StringVar(&chainAClientId, "chain-a-client-id", "hub-testnet-1", "Chain A client ID")
 	cmd.Flags().StringVar(&chainADenom, "chain-a-denom", "0xfFf9976782d46CC05630D1f6eBAb18b2324d6B14", "Chain A denom")
@@ -181,6 +189,7 @@ func run(
 	chainBRelayerWallet network.Wallet,
 	transferAmountBig *big.Int,
 	numPacketsPerWallet int,
+	msgsPerTx int,
 	selfRelay bool,
 ) error {
 	transferStatusModelAToB := tui.NewStatusModel(fmt.Sprintf("Transferring from %s to %s 0/0", chainA.GetChainID(), chainB.GetChainID()))
@@ -202,6 +211,7 @@ func run(
 		chainBRelayerWallet,
 		transferAmountBig,
 		numPacketsPerWallet,
+		msgsPerTx,
 		selfRelay,
 	)
 	if err != nil {
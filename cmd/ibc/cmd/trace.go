@@ -23,6 +23,7 @@ func traceCmd() *cobra.Command {
 			if err != nil {
 				return errors.Wrap(err, "failed to build network")
 			}
+			defer network.Close()
 
 			chain, err := network.GetChain(args[0])
 			if err != nil {
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"math/big"
 	"os"
 
 	"github.com/gjermundgaraba/libibc/chains/network"
@@ -23,6 +22,13 @@ func transferCmd() *cobra.Command {
 		Use:   "transfer [from-chain-id] [to-chain-id] [source-client] [from-wallet-id] [amount] [denom] [to-address] [memo]",
 		Args:  cobra.ExactArgs(8),
 		Short: "Transfer tokens between two chains",
+		Long: `Transfer tokens between two chains.
+The source-client is either an IBC v2 client ID (e.g. 08-wasm-0) or an IBC v1 channel ID (e.g. channel-0) for classic ICS20 transfers.
+On Ethereum chains, the denom is either an ERC20 contract address, the full denom path of a token received over IBC
+(e.g. transfer/08-wasm-274/uatom) or "eth" for native ETH, which is wrapped into WETH (using the weth-address from
+the chain config) before it is transferred.
+The amount is either an integer in base units (e.g. 1500000 or 1500000uatom) or an amount with decimals or followed by
+the token symbol (e.g. 1.5, 1.5ATOM or 0.01WETH), which is converted using the token decimals.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
@@ -41,6 +47,7 @@ func transferCmd() *cobra.Command {
 			if err != nil {
 				return errors.Wrap(err, "failed to build network")
 			}
+			defer networkConfig.Close()
 
 			fromChain, err := networkConfig.GetChain(fromChainID)
 			if err != nil {
@@ -57,9 +64,9 @@ func transferCmd() *cobra.Command {
 				return errors.Wrapf(err, "failed to get from-wallet %s", fromWalletID)
 			}
 
-			amount, ok := new(big.Int).SetString(amountStr, 10)
-			if !ok {
-				return errors.Errorf("failed to parse amount %s", amountStr)
+			amount, err := parseAmount(ctx, fromChain, amountStr, denom)
+			if err != nil {
+				return errors.Wrapf(err, "failed to parse amount %s", amountStr)
 			}
 
 			var relayerWallet network.Wallet
@@ -76,7 +83,7 @@ func transferCmd() *cobra.Command {
 				errGroup := errgroup.Group{}
 
 				errGroup.Go(func() error {
-					logger.Info("Sending transfer", zap.String("from-chain", fromChainID), zap.String("to-chain", toChainID), zap.String("from-wallet", fromWalletID), zap.String("amount", amount.String()), zap.String("denom", denom), zap.String("to-address", toAddress))
+					logger.Info("Sending transfer", zap.String("from-chain", fromChainID), zap.String("to-chain", toChainID), zap.String("from-wallet", fromWalletID), zap.String("amount", network.FormatAmount(amount, displayMetadata(ctx, fromChain, denom))), zap.String("denom", denom), zap.String("to-address", toAddress))
 
 					tuiInstance.UpdateMainStatus("Transferring...")
 
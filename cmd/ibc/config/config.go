@@ -3,12 +3,19 @@ package config
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"os"
+	"slices"
+	"time"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/gjermundgaraba/libibc/chains/cosmos"
 	"github.com/gjermundgaraba/libibc/chains/ethereum"
+	"github.com/gjermundgaraba/libibc/chains/ethereum/beaconapi"
 	"github.com/gjermundgaraba/libibc/chains/network"
 	"github.com/gjermundgaraba/libibc/cmd/ibc/relayer"
+	"github.com/gjermundgaraba/libibc/utils"
 	"github.com/pelletier/go-toml"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -30,9 +37,55 @@ type ChainConfig struct {
 	Clients   []ClientConfig `toml:"clients"`
 	WalletIDs []string       `toml:"wallet-ids"`
 
+	// Fallback endpoints, used after rpc-addr and grpc-addr (which can be left empty if these are set)
+	RPCAddrs  []string `toml:"rpc-addrs"`
+	GRPCAddrs []string `toml:"grpc-addrs"`
+	// EndpointSelection is either "priority" (default), which uses the first endpoint that works, or "round-robin"
+	EndpointSelection string `toml:"endpoint-selection"`
+
+	// gRPC transport. Addresses can also choose TLS with their scheme (https:// or grpcs://, http:// or grpc://)
+	GRPCTLS        bool   `toml:"grpc-tls"`
+	GRPCCAFile     string `toml:"grpc-ca-file"`
+	GRPCCertFile   string `toml:"grpc-cert-file"`
+	GRPCKeyFile    string `toml:"grpc-key-file"`
+	GRPCServerName string `toml:"grpc-server-name"`
+	// GRPCHeaders are sent with every gRPC call, e.g. an API key. Values can use environment variables: "Bearer ${API_KEY}"
+	GRPCHeaders map[string]string `toml:"grpc-headers"`
+	// GRPCInsecureHeaders allows sending the headers over plaintext connections, which are otherwise refused
+	GRPCInsecureHeaders bool `toml:"grpc-insecure-headers"`
+
+	// Cosmos specific fields
+	Bech32Prefix string `toml:"bech32-prefix"`
+	KeyType      string `toml:"key-type"`
+
 	// Ethereum specific fields
-	ICS26Address         string `toml:"ics26-address"`
-	RelayerHelperAddress string `toml:"relayer-helper-address"`
+	ICS26Address         string  `toml:"ics26-address"`
+	RelayerHelperAddress string  `toml:"relayer-helper-address"`
+	LegacyGasPricing     bool    `toml:"legacy-gas-pricing"`
+	TipMultiplier        float64 `toml:"tip-multiplier"`
+	FeeCapMultiplier     float64 `toml:"fee-cap-multiplier"`
+	MaxFeeGwei           int64   `toml:"max-fee-gwei"`
+	GasLimitMultiplier   float64 `toml:"gas-limit-multiplier"`
+	BumpAfterSeconds     int64   `toml:"bump-after-seconds"`
+	BumpPercent          int64   `toml:"bump-percent"`
+	MineTimeoutSeconds   int64   `toml:"mine-timeout-seconds"`
+	ApprovalStrategy     string  `toml:"approval-strategy"`
+	WETHAddress          string  `toml:"weth-address"`
+	// RPCAuth is sent with every JSON-RPC request, for providers that need an API key or a JWT
+	RPCAuth AuthConfig `toml:"rpc-auth"`
+	// BeaconAPIAddr is the beacon API of the chain's consensus layer, with BeaconAPIAuth sent with every request
+	BeaconAPIAddr string     `toml:"beacon-api-addr"`
+	BeaconAPIAuth AuthConfig `toml:"beacon-api-auth"`
+}
+
+// AuthConfig is the authentication for an HTTP endpoint (see utils.HTTPAuth).
+// All values can use environment variables, e.g. "${ALCHEMY_API_KEY}", to keep secrets out of the config file.
+type AuthConfig struct {
+	Headers           map[string]string `toml:"headers"`
+	BearerToken       string            `toml:"bearer-token"`
+	JWTSecret         string            `toml:"jwt-secret"`
+	BasicAuthUser     string            `toml:"basic-auth-user"`
+	BasicAuthPassword string            `toml:"basic-auth-password"`
 }
 
 // ClientConfig represents the configuration for a client
@@ -118,18 +171,64 @@ func (c *Config) ToNetwork(ctx context.Context, logger *zap.Logger, extraGwei in
 			chain network.Chain
 			err   error
 		)
+
+		selection, err := utils.ParseSelectionStrategy(chainConfig.EndpointSelection)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid endpoint selection for chain %s", chainConfig.ChainID)
+		}
+		rpcEndpoints, err := chainConfig.endpointPool(logger, "rpc", chainConfig.RPCAddr, chainConfig.RPCAddrs, selection)
+		if err != nil {
+			return nil, err
+		}
+
 		switch chainConfig.ChainType {
 		case "cosmos":
-			chain, err = cosmos.NewCosmos(logger, chainConfig.ChainID, chainConfig.GRPCAddr)
+			grpcEndpoints, err := chainConfig.endpointPool(logger, "grpc", chainConfig.GRPCAddr, chainConfig.GRPCAddrs, selection)
+			if err != nil {
+				return nil, err
+			}
+
+			cosmosChain, err := cosmos.NewCosmosWithEndpoints(logger, chainConfig.ChainID, grpcEndpoints, rpcEndpoints, chainConfig.Bech32Prefix, chainConfig.KeyType)
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to create Cosmos chain")
 			}
+			if err := cosmosChain.SetGRPCConfig(chainConfig.grpcConfig()); err != nil {
+				return nil, err
+			}
+
+			chain = cosmosChain
 		case "ethereum":
-			ethChain, err := ethereum.NewEthereum(ctx, logger, chainConfig.ChainID, chainConfig.RPCAddr, chainConfig.ICS26Address, chainConfig.RelayerHelperAddress)
+			if rpcEndpoints == nil {
+				return nil, errors.Errorf("no rpc address configured for chain %s", chainConfig.ChainID)
+			}
+
+			ethChain, err := ethereum.NewEthereumWithEndpoints(ctx, logger, chainConfig.ChainID, rpcEndpoints, chainConfig.RPCAuth.httpAuth(), chainConfig.ICS26Address, chainConfig.RelayerHelperAddress)
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to create Ethereum chain")
 			}
-			ethChain.SetExtraGwei(extraGwei)
+
+			if chainConfig.BeaconAPIAddr != "" {
+				beaconAPI, err := beaconapi.NewBeaconAPIClientWithAuth(logger, chainConfig.BeaconAPIAddr, chainConfig.BeaconAPIAuth.httpAuth())
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to create beacon api client for chain %s", chainConfig.ChainID)
+				}
+				ethChain.SetBeaconAPI(beaconAPI)
+			}
+			ethChain.SetFeeConfig(chainConfig.feeConfig(extraGwei))
+
+			approvalStrategy, err := ethereum.ParseApprovalStrategy(chainConfig.ApprovalStrategy)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid approval strategy for chain %s", chainConfig.ChainID)
+			}
+			ethChain.SetApprovalStrategy(approvalStrategy)
+
+			if chainConfig.WETHAddress != "" {
+				if !ethcommon.IsHexAddress(chainConfig.WETHAddress) {
+					return nil, errors.Errorf("invalid weth address for chain %s: %s", chainConfig.ChainID, chainConfig.WETHAddress)
+				}
+				ethChain.SetWETHAddress(ethcommon.HexToAddress(chainConfig.WETHAddress))
+			}
+
 			chain = ethChain
 		default:
 			panic(fmt.Sprintf("unsupported chain type: %s", chainConfig.ChainType))
@@ -160,3 +259,86 @@ func (c *Config) ToNetwork(ctx context.Context, logger *zap.Logger, extraGwei in
 	relayer := relayer.NewRelayer(logger, c.RelayerGRPCAddr)
 	return network.BuildNetwork(logger, chains, relayer)
 }
+
+// endpointPool returns the pool of the chain's endpoints of the kind (rpc or grpc), with the main address first,
+// or nil if none are configured.
+func (cc ChainConfig) endpointPool(logger *zap.Logger, kind string, addr string, fallbackAddrs []string, selection utils.SelectionStrategy) (*utils.EndpointPool, error) {
+	addrs := append([]string{addr}, fallbackAddrs...)
+	if !slices.ContainsFunc(addrs, func(addr string) bool { return addr != "" }) {
+		return nil, nil
+	}
+
+	pool, err := utils.NewEndpointPool(logger, cc.ChainID+" "+kind, addrs, selection)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s endpoints for chain %s", kind, cc.ChainID)
+	}
+
+	return pool, nil
+}
+
+// httpAuth returns the auth with environment variables expanded
+func (ac AuthConfig) httpAuth() utils.HTTPAuth {
+	headers := make(map[string]string, len(ac.Headers))
+	for key, value := range ac.Headers {
+		headers[key] = os.ExpandEnv(value)
+	}
+
+	return utils.HTTPAuth{
+		Headers:           headers,
+		BearerToken:       os.ExpandEnv(ac.BearerToken),
+		JWTSecret:         os.ExpandEnv(ac.JWTSecret),
+		BasicAuthUser:     os.ExpandEnv(ac.BasicAuthUser),
+		BasicAuthPassword: os.ExpandEnv(ac.BasicAuthPassword),
+	}
+}
+
+// grpcConfig returns the gRPC transport config for the chain, with environment variables in the header values expanded
+func (cc ChainConfig) grpcConfig() utils.GRPCConfig {
+	headers := make(map[string]string, len(cc.GRPCHeaders))
+	for key, value := range cc.GRPCHeaders {
+		headers[key] = os.ExpandEnv(value)
+	}
+
+	return utils.GRPCConfig{
+		TLS:             cc.GRPCTLS,
+		CAFile:          cc.GRPCCAFile,
+		CertFile:        cc.GRPCCertFile,
+		KeyFile:         cc.GRPCKeyFile,
+		ServerName:      cc.GRPCServerName,
+		Headers:         headers,
+		InsecureHeaders: cc.GRPCInsecureHeaders,
+	}
+}
+
+// feeConfig returns the ethereum fee config for the chain, using the defaults for anything not set.
+// A negative bump-after-seconds disables fee bumping.
+func (cc ChainConfig) feeConfig(extraGwei int64) ethereum.FeeConfig {
+	feeConfig := ethereum.DefaultFeeConfig()
+	feeConfig.LegacyPricing = cc.LegacyGasPricing
+	feeConfig.ExtraGwei = extraGwei
+	if cc.TipMultiplier > 0 {
+		feeConfig.TipMultiplier = cc.TipMultiplier
+	}
+	if cc.FeeCapMultiplier > 0 {
+		feeConfig.FeeCapMultiplier = cc.FeeCapMultiplier
+	}
+	if cc.MaxFeeGwei > 0 {
+		feeConfig.MaxFeePerGas = new(big.Int).Mul(big.NewInt(cc.MaxFeeGwei), big.NewInt(params.GWei))
+	}
+	if cc.GasLimitMultiplier > 0 {
+		feeConfig.GasLimitMultiplier = cc.GasLimitMultiplier
+	}
+	if cc.BumpAfterSeconds > 0 {
+		feeConfig.BumpAfter = time.Duration(cc.BumpAfterSeconds) * time.Second
+	} else if cc.BumpAfterSeconds < 0 {
+		feeConfig.BumpAfter = 0
+	}
+	if cc.BumpPercent > 0 {
+		feeConfig.BumpPercent = cc.BumpPercent
+	}
+	if cc.MineTimeoutSeconds > 0 {
+		feeConfig.MineTimeout = time.Duration(cc.MineTimeoutSeconds) * time.Second
+	}
+
+	return feeConfig
+}
@@ -4,8 +4,11 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/gjermundgaraba/libibc/utils"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -79,4 +82,62 @@ func TestLoadConfig_Error(t *testing.T) {
 	config, err := LoadConfig("non_existent_file.toml")
 	assert.Error(t, err)
 	assert.Nil(t, config)
-}
\ No newline at end of file
+}
+
+func TestChainConfigFeeConfig(t *testing.T) {
+	defaultFeeConfig := ChainConfig{}.feeConfig(3)
+	assert.False(t, defaultFeeConfig.LegacyPricing)
+	assert.Equal(t, float64(1), defaultFeeConfig.TipMultiplier)
+	assert.Equal(t, float64(2), defaultFeeConfig.FeeCapMultiplier)
+	assert.Nil(t, defaultFeeConfig.MaxFeePerGas)
+	assert.Equal(t, int64(3), defaultFeeConfig.ExtraGwei)
+	assert.Equal(t, 1.2, defaultFeeConfig.GasLimitMultiplier)
+	assert.Equal(t, 30*time.Second, defaultFeeConfig.BumpAfter)
+	assert.Equal(t, int64(20), defaultFeeConfig.BumpPercent)
+	assert.Equal(t, 10*time.Minute, defaultFeeConfig.MineTimeout)
+
+	feeConfig := ChainConfig{
+		LegacyGasPricing:   true,
+		TipMultiplier:      1.5,
+		FeeCapMultiplier:   3,
+		MaxFeeGwei:         100,
+		GasLimitMultiplier: 1.5,
+		BumpAfterSeconds:   10,
+		BumpPercent:        50,
+		MineTimeoutSeconds: 1800,
+	}.feeConfig(0)
+	assert.True(t, feeConfig.LegacyPricing)
+	assert.Equal(t, 1.5, feeConfig.TipMultiplier)
+	assert.Equal(t, float64(3), feeConfig.FeeCapMultiplier)
+	assert.Equal(t, "100000000000", feeConfig.MaxFeePerGas.String())
+	assert.Equal(t, 1.5, feeConfig.GasLimitMultiplier)
+	assert.Equal(t, 10*time.Second, feeConfig.BumpAfter)
+	assert.Equal(t, int64(50), feeConfig.BumpPercent)
+	assert.Equal(t, 30*time.Minute, feeConfig.MineTimeout)
+
+	// A negative bump after disables fee bumping
+	noBumpFeeConfig := ChainConfig{BumpAfterSeconds: -1}.feeConfig(0)
+	assert.Zero(t, noBumpFeeConfig.BumpAfter)
+}
+
+func TestChainConfigEndpointPool(t *testing.T) {
+	chainConfig := ChainConfig{
+		ChainID:   "test-chain",
+		GRPCAddr:  "localhost:9090",
+		GRPCAddrs: []string{"localhost:9091", "localhost:9090"},
+	}
+
+	grpcEndpoints, err := chainConfig.endpointPool(zap.NewNop(), "grpc", chainConfig.GRPCAddr, chainConfig.GRPCAddrs, utils.SelectionPriority)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"localhost:9090", "localhost:9091"}, grpcEndpoints.Addrs())
+
+	// Only fallback endpoints
+	chainConfig.GRPCAddr = ""
+	grpcEndpoints, err = chainConfig.endpointPool(zap.NewNop(), "grpc", chainConfig.GRPCAddr, chainConfig.GRPCAddrs, utils.SelectionPriority)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"localhost:9091", "localhost:9090"}, grpcEndpoints.Addrs())
+
+	rpcEndpoints, err := chainConfig.endpointPool(zap.NewNop(), "rpc", chainConfig.RPCAddr, chainConfig.RPCAddrs, utils.SelectionPriority)
+	assert.NoError(t, err)
+	assert.Nil(t, rpcEndpoints)
+}
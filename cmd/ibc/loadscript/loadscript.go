@@ -37,7 +37,7 @@ const (
 func TransferAndRelayFromAToB(
 	ctx context.Context,
 	logger *zap.Logger,
-	network *network.Network,
+	ibcNetwork *network.Network,
 	fromChain network.Chain,
 	fromClientId string,
 	denom string,
@@ -47,13 +47,23 @@ func TransferAndRelayFromAToB(
 	toChainRelayerWallet network.Wallet,
 	transferAmount *big.Int,
 	numPacketsPerWallet int,
+	msgsPerTx int,
 	selfRelay bool,
 ) (chan ProgressUpdate, error) {
-	relayerQueue := network.NewRelayerQueue(logger, fromChain, toChain, toChainRelayerWallet, 10, selfRelay)
+	relayerQueue := ibcNetwork.NewRelayerQueue(logger, fromChain, toChain, toChainRelayerWallet, 10, selfRelay)
 	progressCh := make(chan ProgressUpdate, 100)
 
 	aToBUpdateMutext := sync.Mutex{}
 
+	batchTransferer, canBatch := fromChain.(network.BatchTransferer)
+	if msgsPerTx > 1 && !canBatch {
+		logger.Warn("Chain does not support batched transfers, sending one transfer per tx", zap.String("chain", fromChain.GetChainID()), zap.Int("msgs-per-tx", msgsPerTx))
+		msgsPerTx = 1
+	}
+	if msgsPerTx < 1 {
+		msgsPerTx = 1
+	}
+
 	totalTransfer := len(toWallets) * numPacketsPerWallet
 	transferCompleted := 0
 
@@ -86,20 +96,41 @@ func TransferAndRelayFromAToB(
 				chainAWallet := fromWallets[idx]
 				chainBWallet := toWallets[idx]
 
-				for range numPacketsPerWallet {
-					var packet ibc.Packet
+				for sent := 0; sent < numPacketsPerWallet; {
+					batchSize := min(msgsPerTx, numPacketsPerWallet-sent)
+
+					var packets []ibc.Packet
 					if err := withRetry(func() error {
 						var err error
-						packet, err = fromChain.SendTransfer(ctx, fromClientId, chainAWallet, transferAmount, denom, chainBWallet.Address(), "")
+						if batchSize == 1 {
+							var packet ibc.Packet
+							packet, err = fromChain.SendTransfer(ctx, fromClientId, chainAWallet, transferAmount, denom, chainBWallet.Address(), "")
+							packets = []ibc.Packet{packet}
+							return err
+						}
+
+						transfers := make([]network.Transfer, batchSize)
+						for i := range transfers {
+							transfers[i] = network.Transfer{
+								Amount: transferAmount,
+								Denom:  denom,
+								To:     chainBWallet.Address(),
+							}
+						}
+						packets, err = batchTransferer.SendTransfers(ctx, fromClientId, chainAWallet, transfers)
 						return err
 					}); err != nil {
 						reportErr(err)
 						return errors.Wrapf(err, "failed to create transfer from %s to chain %s", fromChain.GetChainID(), toChain.GetChainID())
 					}
-					relayerQueue.Add(packet)
+					sent += batchSize
+
+					for _, packet := range packets {
+						relayerQueue.Add(packet)
+					}
 
 					aToBUpdateMutext.Lock()
-					transferCompleted++
+					transferCompleted += len(packets)
 
 					inQueue, _, completedRelaying := relayerQueue.Status()
 
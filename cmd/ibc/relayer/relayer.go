@@ -4,17 +4,24 @@ import (
 	context "context"
 	"encoding/hex"
 	"strings"
+	"sync"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/gjermundgaraba/libibc/chains/network"
 	"github.com/gjermundgaraba/libibc/utils"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 )
 
 type Relayer struct {
 	grpcAddr string
 	logger   *zap.Logger
+
+	// connMu guards conn, which is created on first use and reused until Close
+	connMu sync.Mutex
+	conn   *grpc.ClientConn
 }
 
 var _ network.Relayer = &Relayer{}
@@ -26,10 +33,45 @@ func NewRelayer(logger *zap.Logger, relayerGRPCAddr string) *Relayer {
 	}
 }
 
-func (r *Relayer) Relay(ctx context.Context, srcChain network.Chain, dstChain network.Chain, srcClient string, dstClient string, relayerWallet network.Wallet, txIds []string) (string, error) {
+// Close closes the connection to the relayer.
+func (r *Relayer) Close() error {
+	r.connMu.Lock()
+	defer r.connMu.Unlock()
+
+	if r.conn == nil {
+		return nil
+	}
+
+	err := r.conn.Close()
+	r.conn = nil
+	if err != nil {
+		return errors.Wrap(err, "failed to close relayer grpc connection")
+	}
+
+	return nil
+}
+
+func (r *Relayer) getGRPC() (*grpc.ClientConn, error) {
+	r.connMu.Lock()
+	defer r.connMu.Unlock()
+
+	if r.conn != nil && r.conn.GetState() != connectivity.Shutdown {
+		return r.conn, nil
+	}
+
 	conn, err := utils.GetGRPC(r.grpcAddr)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to get grpc connection")
+		return nil, errors.Wrap(err, "failed to get grpc connection")
+	}
+	r.conn = conn
+
+	return r.conn, nil
+}
+
+func (r *Relayer) Relay(ctx context.Context, srcChain network.Chain, dstChain network.Chain, srcClient string, dstClient string, relayerWallet network.Wallet, txIds []string) (string, error) {
+	conn, err := r.getGRPC()
+	if err != nil {
+		return "", err
 	}
 
 	txIdsBytes := make([][]byte, len(txIds))
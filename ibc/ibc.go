@@ -14,6 +14,18 @@ type Packet struct {
 	SourceClient      string
 	DestinationClient string
 	TimeoutTimestamp  uint64
+	// EventIndex is the position of the send packet event in the tx, used to order packets from the same tx.
+	// Only set for Ethereum packets, where it is the log index.
+	EventIndex uint
+	// Height is the block height of the tx, only set when known (e.g. for packets found by scanning blocks).
+	Height uint64
+
+	// Only set for IBC v1 (channel-based) packets.
+	// For those, SourceClient and DestinationClient are set to the channel IDs.
+	SourcePort         string
+	SourceChannel      string
+	DestinationPort    string
+	DestinationChannel string
 
 	PacketRaw any
 }
@@ -38,6 +50,23 @@ func NewPacket(
 	}
 }
 
+// NewV1Packet creates a Packet from an IBC v1 (channel-based) packet.
+func NewV1Packet(txHash string, packet channeltypes.Packet) Packet {
+	return Packet{
+		TxHash:             txHash,
+		IBCVersion:         1,
+		Sequence:           packet.Sequence,
+		SourceClient:       packet.SourceChannel,
+		DestinationClient:  packet.DestinationChannel,
+		TimeoutTimestamp:   packet.TimeoutTimestamp,
+		SourcePort:         packet.SourcePort,
+		SourceChannel:      packet.SourceChannel,
+		DestinationPort:    packet.DestinationPort,
+		DestinationChannel: packet.DestinationChannel,
+		PacketRaw:          packet,
+	}
+}
+
 func (p Packet) GetTransferData() (transfertypes.InternalTransferRepresentation, error) {
 	var packetDataBz []byte
 	encoding := transfertypes.EncodingJSON
@@ -11,12 +11,14 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/gjermundgaraba/libibc/chains/ethereum"
 	"github.com/gjermundgaraba/libibc/chains/ethereum/beaconapi"
+	"github.com/gjermundgaraba/libibc/chains/ethereum/solidity"
 	"github.com/gjermundgaraba/libibc/chains/network"
 	"github.com/gjermundgaraba/libibc/utils"
 	"github.com/kurtosis-tech/kurtosis/api/golang/core/lib/enclaves"
 	"github.com/kurtosis-tech/kurtosis/api/golang/core/lib/services"
 	"github.com/kurtosis-tech/kurtosis/api/golang/core/lib/starlark_run_config"
 	"github.com/kurtosis-tech/kurtosis/api/golang/engine/lib/kurtosis_context"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
@@ -36,7 +38,9 @@ type EthKurtosisChain struct {
 	consensusService string
 }
 
-func SpinUpEthereum(ctx context.Context, logger *zap.Logger, networkParams NetworkParams) (network.Chain, error) {
+// SpinUpEthereum starts an Ethereum testnet with Kurtosis and deploys the IBC contracts to it.
+// The deploy options must have the light client genesis of the counterparty chain (see solidity.DeployOptions).
+func SpinUpEthereum(ctx context.Context, logger *zap.Logger, networkParams NetworkParams, deployOpts solidity.DeployOptions) (network.Chain, error) {
 	executionService := fmt.Sprintf("el-1-%s-%s", networkParams.Participants[0].ELType, networkParams.Participants[0].CLType)
 	consensusService := fmt.Sprintf("cl-1-%s-%s", networkParams.Participants[0].CLType, networkParams.Participants[0].ELType)
 
@@ -93,29 +97,33 @@ func SpinUpEthereum(ctx context.Context, logger *zap.Logger, networkParams Netwo
 	beaconRPC := fmt.Sprintf("http://localhost:%d", beaconPortSpec.GetNumber())
 
 	if networkParams.WaitForFinalization {
-		var beaconAPIClient beaconapi.Client
-		err = utils.WaitForCondition(30*time.Minute, 5*time.Second, func() (bool, error) {
-			beaconAPIClient, err = beaconapi.NewBeaconAPIClient(beaconRPC)
-			if err != nil {
-				return false, nil
-			}
+		beaconAPIClient, err := beaconapi.NewBeaconAPIClient(logger, beaconRPC)
+		if err != nil {
+			return nil, err
+		}
+		defer beaconAPIClient.Close()
 
-			finalizedBlocksResp, err := beaconAPIClient.GetFinalizedBlocks()
-			fmt.Printf("Waiting for chain to finalize, finalizedBlockResp: %+v, err: %s\n", finalizedBlocksResp, err)
-			if err != nil {
-				return false, nil
+		// Not being finalized yet, or the node not being up yet, is expected while waiting, so only other errors end the wait
+		waitError := func(err error) error {
+			if errors.Is(err, beaconapi.ErrNotFinalized) || errors.Is(err, beaconapi.ErrNotFound) || beaconapi.IsRetryable(err) {
+				logger.Info("Waiting for chain to finalize", zap.Error(err))
+				return nil
 			}
-			if !finalizedBlocksResp.Finalized {
-				return false, nil
+			return err
+		}
+		err = utils.WaitForCondition(30*time.Minute, 5*time.Second, func() (bool, error) {
+			finalizedBlocksResp, err := beaconAPIClient.GetFinalizedBlocks(ctx)
+			if err != nil {
+				return false, waitError(err)
 			}
 
-			header, err := beaconAPIClient.GetBeaconBlockHeader(finalizedBlocksResp.Data.Message.Slot)
+			header, err := beaconAPIClient.GetBeaconBlockHeader(ctx, finalizedBlocksResp.Data.Message.Slot)
 			if err != nil {
-				return false, nil
+				return false, waitError(err)
 			}
-			bootstrap, err := beaconAPIClient.GetBootstrap(header.Data.Root)
+			bootstrap, err := beaconAPIClient.GetBootstrap(ctx, header.Data.Root)
 			if err != nil {
-				return false, nil
+				return false, waitError(err)
 			}
 
 			return bootstrap.Data.Header.Beacon.Slot != 0, nil
@@ -144,6 +152,7 @@ func SpinUpEthereum(ctx context.Context, logger *zap.Logger, networkParams Netwo
 		chainID.String(),
 		ethRPC,
 		faucetPrivKey,
+		deployOpts,
 	)
 	if err != nil {
 		return nil, err
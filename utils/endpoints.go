@@ -0,0 +1,234 @@
+package utils
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// endpointBaseCooldown is how long a failed endpoint is skipped for, doubled for every consecutive failure
+	endpointBaseCooldown = 5 * time.Second
+	endpointMaxCooldown  = 2 * time.Minute
+)
+
+// SelectionStrategy decides which of a chain's endpoints is tried first.
+type SelectionStrategy string
+
+const (
+	// SelectionPriority always tries the endpoints in the configured order, so the later ones are only used as fallbacks.
+	SelectionPriority SelectionStrategy = "priority"
+	// SelectionRoundRobin spreads the requests over all the healthy endpoints.
+	SelectionRoundRobin SelectionStrategy = "round-robin"
+)
+
+// ParseSelectionStrategy parses an endpoint selection strategy, where an empty string means the default (priority).
+func ParseSelectionStrategy(strategy string) (SelectionStrategy, error) {
+	switch SelectionStrategy(strategy) {
+	case "":
+		return SelectionPriority, nil
+	case SelectionPriority, SelectionRoundRobin:
+		return SelectionStrategy(strategy), nil
+	default:
+		return "", errors.Errorf("unknown endpoint selection strategy: %s (must be one of %s or %s)", strategy, SelectionPriority, SelectionRoundRobin)
+	}
+}
+
+// EndpointPool is a set of interchangeable endpoints (e.g. the gRPC addresses of a chain) that requests fail over between.
+// An endpoint that fails is considered unhealthy and skipped for a cooldown that grows with every consecutive failure,
+// after which it is tried again. Unhealthy endpoints are still used as a last resort when all the others fail too.
+type EndpointPool struct {
+	name     string
+	strategy SelectionStrategy
+	logger   *zap.Logger
+
+	mu        sync.Mutex
+	endpoints []*endpointState
+	next      int
+}
+
+type endpointState struct {
+	addr                string
+	successes           uint64
+	failures            uint64
+	consecutiveFailures uint64
+	unhealthyUntil      time.Time
+	lastErr             error
+}
+
+// NewEndpointPool creates a pool of the addresses, where empty and duplicate addresses are ignored.
+// The name is used in the logs, e.g. "cosmoshub-4 grpc".
+func NewEndpointPool(logger *zap.Logger, name string, addrs []string, strategy SelectionStrategy) (*EndpointPool, error) {
+	pool := &EndpointPool{
+		name:     name,
+		strategy: strategy,
+		logger:   logger,
+	}
+
+	seen := make(map[string]bool)
+	for _, addr := range addrs {
+		addr = strings.TrimSpace(addr)
+		if addr == "" || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		pool.endpoints = append(pool.endpoints, &endpointState{addr: addr})
+	}
+
+	if len(pool.endpoints) == 0 {
+		return nil, errors.Errorf("no endpoints given for %s", name)
+	}
+
+	return pool, nil
+}
+
+// Name returns the name of the pool used in the logs.
+func (p *EndpointPool) Name() string {
+	return p.name
+}
+
+// Primary returns the first configured endpoint.
+func (p *EndpointPool) Primary() string {
+	return p.endpoints[0].addr
+}
+
+// Addrs returns all the endpoints in the configured order.
+func (p *EndpointPool) Addrs() []string {
+	addrs := make([]string, len(p.endpoints))
+	for i, endpoint := range p.endpoints {
+		addrs[i] = endpoint.addr
+	}
+
+	return addrs
+}
+
+// Order returns the endpoints in the order they should be tried for the next request:
+// the healthy ones by the selection strategy, followed by the unhealthy ones, soonest to recover first.
+func (p *EndpointPool) Order() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var healthy, unhealthy []*endpointState
+	for _, endpoint := range p.endpoints {
+		if now.Before(endpoint.unhealthyUntil) {
+			unhealthy = append(unhealthy, endpoint)
+		} else {
+			healthy = append(healthy, endpoint)
+		}
+	}
+
+	if p.strategy == SelectionRoundRobin && len(healthy) > 1 {
+		start := p.next % len(healthy)
+		healthy = slices.Concat(healthy[start:], healthy[:start])
+		p.next++
+	}
+
+	sort.SliceStable(unhealthy, func(i, j int) bool {
+		return unhealthy[i].unhealthyUntil.Before(unhealthy[j].unhealthyUntil)
+	})
+
+	addrs := make([]string, 0, len(p.endpoints))
+	for _, endpoint := range append(healthy, unhealthy...) {
+		addrs = append(addrs, endpoint.addr)
+	}
+
+	return addrs
+}
+
+// ReportSuccess marks the endpoint as healthy.
+func (p *EndpointPool) ReportSuccess(addr string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	endpoint := p.endpoint(addr)
+	if endpoint == nil {
+		return
+	}
+
+	endpoint.successes++
+	if endpoint.consecutiveFailures > 0 {
+		p.logger.Info("Endpoint recovered",
+			zap.String("endpoints", p.name),
+			zap.String("endpoint", addr),
+			zap.Uint64("failed_attempts", endpoint.consecutiveFailures))
+	}
+	endpoint.consecutiveFailures = 0
+	endpoint.unhealthyUntil = time.Time{}
+}
+
+// ReportFailure marks the endpoint as unhealthy for a cooldown, and logs the error with the endpoint's error stats.
+func (p *EndpointPool) ReportFailure(addr string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	endpoint := p.endpoint(addr)
+	if endpoint == nil {
+		return
+	}
+
+	endpoint.failures++
+	endpoint.consecutiveFailures++
+	endpoint.lastErr = err
+
+	cooldown := endpointMaxCooldown
+	if endpoint.consecutiveFailures < 16 {
+		cooldown = min(endpointBaseCooldown<<(endpoint.consecutiveFailures-1), endpointMaxCooldown)
+	}
+	endpoint.unhealthyUntil = time.Now().Add(cooldown)
+
+	p.logger.Warn("Endpoint failed",
+		zap.String("endpoints", p.name),
+		zap.String("endpoint", addr),
+		zap.Error(err),
+		zap.Uint64("failures", endpoint.failures),
+		zap.Uint64("successes", endpoint.successes),
+		zap.Uint64("consecutive_failures", endpoint.consecutiveFailures),
+		zap.Duration("retry_after", cooldown))
+}
+
+// LogStats logs the success and error counts of every endpoint that has failed at least once.
+func (p *EndpointPool) LogStats() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, endpoint := range p.endpoints {
+		if endpoint.failures == 0 {
+			continue
+		}
+
+		p.logger.Info("Endpoint stats",
+			zap.String("endpoints", p.name),
+			zap.String("endpoint", endpoint.addr),
+			zap.Uint64("failures", endpoint.failures),
+			zap.Uint64("successes", endpoint.successes),
+			zap.NamedError("last_error", endpoint.lastErr))
+	}
+}
+
+func (p *EndpointPool) endpoint(addr string) *endpointState {
+	idx := slices.IndexFunc(p.endpoints, func(endpoint *endpointState) bool {
+		return endpoint.addr == addr
+	})
+	if idx == -1 {
+		return nil
+	}
+
+	return p.endpoints[idx]
+}
+
+// AllHTTP returns true if all the endpoints are http(s) URLs, which FailoverTransport can fail over between.
+func (p *EndpointPool) AllHTTP() bool {
+	for _, endpoint := range p.endpoints {
+		if _, err := endpointURL(endpoint.addr); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEndpointPoolOrder(t *testing.T) {
+	pool, err := NewEndpointPool(zap.NewNop(), "test", []string{"a", "b", "", "c", "a"}, SelectionPriority)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c"}, pool.Addrs())
+	require.Equal(t, []string{"a", "b", "c"}, pool.Order())
+	require.Equal(t, []string{"a", "b", "c"}, pool.Order())
+
+	// A failed endpoint is moved to the back until it recovers
+	pool.ReportFailure("a", errors.New("connection refused"))
+	require.Equal(t, []string{"b", "c", "a"}, pool.Order())
+	pool.ReportFailure("b", errors.New("rate limited"))
+	require.Equal(t, []string{"c", "a", "b"}, pool.Order())
+
+	pool.ReportSuccess("a")
+	require.Equal(t, []string{"a", "c", "b"}, pool.Order())
+
+	_, err = NewEndpointPool(zap.NewNop(), "test", []string{""}, SelectionPriority)
+	require.Error(t, err)
+}
+
+func TestEndpointPoolRoundRobin(t *testing.T) {
+	pool, err := NewEndpointPool(zap.NewNop(), "test", []string{"a", "b", "c"}, SelectionRoundRobin)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c"}, pool.Order())
+	require.Equal(t, []string{"b", "c", "a"}, pool.Order())
+	require.Equal(t, []string{"c", "a", "b"}, pool.Order())
+
+	// The failed endpoint is left out of the rotation
+	pool.ReportFailure("a", errors.New("connection refused"))
+	require.Equal(t, []string{"c", "b", "a"}, pool.Order())
+	require.Equal(t, []string{"b", "c", "a"}, pool.Order())
+}
+
+func TestParseSelectionStrategy(t *testing.T) {
+	strategy, err := ParseSelectionStrategy("")
+	require.NoError(t, err)
+	require.Equal(t, SelectionPriority, strategy)
+
+	strategy, err = ParseSelectionStrategy("round-robin")
+	require.NoError(t, err)
+	require.Equal(t, SelectionRoundRobin, strategy)
+
+	_, err = ParseSelectionStrategy("random")
+	require.Error(t, err)
+}
+
+func TestFailoverTransport(t *testing.T) {
+	rateLimited := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer rateLimited.Close()
+
+	working := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		w.Write([]byte(r.URL.Path + " " + string(body))) //nolint:errcheck
+	}))
+	defer working.Close()
+
+	pool, err := NewEndpointPool(zap.NewNop(), "test", []string{rateLimited.URL + "/rpc", working.URL}, SelectionPriority)
+	require.NoError(t, err)
+	require.True(t, pool.AllHTTP())
+
+	client := &http.Client{Transport: pool.FailoverTransport(nil)}
+	resp, err := client.Post(rateLimited.URL+"/rpc/status", "text/plain", strings.NewReader("ping"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "/status ping", string(body))
+
+	// The rate limited endpoint is now tried last
+	require.Equal(t, []string{working.URL, rateLimited.URL + "/rpc"}, pool.Order())
+}
+
+func TestIsFailoverError(t *testing.T) {
+	ctx := context.Background()
+	require.True(t, isFailoverError(ctx, status.Error(codes.Unavailable, "connection refused")))
+	require.True(t, isFailoverError(ctx, errors.Wrap(status.Error(codes.ResourceExhausted, "rate limited"), "query failed")))
+	require.False(t, isFailoverError(ctx, status.Error(codes.NotFound, "not found")))
+	require.False(t, isFailoverError(ctx, nil))
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	require.False(t, isFailoverError(cancelledCtx, status.Error(codes.Unavailable, "connection refused")))
+}
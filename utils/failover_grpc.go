@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+var _ grpc.ClientConnInterface = &FailoverConn{}
+
+// FailoverConn is a gRPC connection over all the endpoints of a pool, which can be used with any generated gRPC client.
+// Every call is sent to the endpoints in turn, moving on to the next one if the endpoint is unavailable or rate limits
+// the call. The connection to each endpoint is dialed on first use and kept until Close.
+type FailoverConn struct {
+	pool *EndpointPool
+	dial func(addr string) (*grpc.ClientConn, error)
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// NewFailoverConn creates a failover connection over the pool, using dial to connect to each endpoint.
+func NewFailoverConn(pool *EndpointPool, dial func(addr string) (*grpc.ClientConn, error)) *FailoverConn {
+	return &FailoverConn{
+		pool:  pool,
+		dial:  dial,
+		conns: make(map[string]*grpc.ClientConn),
+	}
+}
+
+// Invoke implements grpc.ClientConnInterface.
+func (c *FailoverConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	var lastErr error
+	for _, addr := range c.pool.Order() {
+		conn, err := c.conn(addr)
+		if err != nil {
+			c.pool.ReportFailure(addr, err)
+			lastErr = err
+			continue
+		}
+
+		err = conn.Invoke(ctx, method, args, reply, opts...)
+		if !isFailoverError(ctx, err) {
+			// Any other error comes from the call itself, so the endpoint is working fine
+			c.pool.ReportSuccess(addr)
+			return err
+		}
+
+		c.pool.ReportFailure(addr, err)
+		lastErr = err
+	}
+
+	return errors.Wrapf(lastErr, "all %s endpoints failed", c.pool.Name())
+}
+
+// NewStream implements grpc.ClientConnInterface.
+// Only opening the stream fails over, as a stream that has started can't be moved to another endpoint.
+func (c *FailoverConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	var lastErr error
+	for _, addr := range c.pool.Order() {
+		conn, err := c.conn(addr)
+		if err != nil {
+			c.pool.ReportFailure(addr, err)
+			lastErr = err
+			continue
+		}
+
+		stream, err := conn.NewStream(ctx, desc, method, opts...)
+		if !isFailoverError(ctx, err) {
+			c.pool.ReportSuccess(addr)
+			return stream, err
+		}
+
+		c.pool.ReportFailure(addr, err)
+		lastErr = err
+	}
+
+	return nil, errors.Wrapf(lastErr, "all %s endpoints failed", c.pool.Name())
+}
+
+// Close closes the connections to all the endpoints and logs the endpoint stats.
+func (c *FailoverConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pool.LogStats()
+
+	var closeErr error
+	for addr, conn := range c.conns {
+		if err := conn.Close(); err != nil && closeErr == nil {
+			closeErr = errors.Wrapf(err, "failed to close grpc connection to %s", addr)
+		}
+		delete(c.conns, addr)
+	}
+
+	return closeErr
+}
+
+// conn returns the connection to the endpoint, dialing it if there is none or it has been shut down.
+// A connection in transient failure is asked to reconnect right away instead of waiting out its backoff.
+func (c *FailoverConn) conn(addr string) (*grpc.ClientConn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if conn, ok := c.conns[addr]; ok {
+		switch conn.GetState() {
+		case connectivity.Shutdown:
+			c.pool.logger.Info("gRPC connection was shut down, reconnecting", zap.String("endpoints", c.pool.Name()), zap.String("endpoint", addr))
+			delete(c.conns, addr)
+		case connectivity.TransientFailure:
+			conn.ResetConnectBackoff()
+			return conn, nil
+		default:
+			return conn, nil
+		}
+	}
+
+	conn, err := c.dial(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial %s", addr)
+	}
+	c.conns[addr] = conn
+
+	return conn, nil
+}
+
+// isFailoverError returns true if the call failed because of the endpoint (it is unavailable or rate limited us),
+// rather than the call itself, and the context is still live so the call can be tried with another endpoint.
+func isFailoverError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
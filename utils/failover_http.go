@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// FailoverTransport returns an http.RoundTripper that sends every request to the endpoints of the pool in turn,
+// moving on to the next one on connection errors, rate limits (429) and gateway errors (502, 503 and 504).
+// Requests must be made to the primary endpoint, which is replaced with the endpoint being tried,
+// so that the same client can be used for all the endpoints.
+// If base is nil, http.DefaultTransport is used.
+func (p *EndpointPool) FailoverTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &failoverTransport{pool: p, base: base}
+}
+
+type failoverTransport struct {
+	pool *EndpointPool
+	base http.RoundTripper
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	primary, err := endpointURL(t.pool.Primary())
+	if err != nil {
+		return nil, err
+	}
+
+	// The body is read up front, so it can be sent again to another endpoint
+	var body []byte
+	if req.Body != nil {
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read request body")
+		}
+	}
+
+	addrs := t.pool.Order()
+	var lastErr error
+	for i, addr := range addrs {
+		endpoint, err := endpointURL(addr)
+		if err != nil {
+			t.pool.ReportFailure(addr, err)
+			lastErr = err
+			continue
+		}
+
+		endpointReq := req.Clone(req.Context())
+		endpointReq.URL.Scheme = endpoint.Scheme
+		endpointReq.URL.Host = endpoint.Host
+		endpointReq.URL.User = endpoint.User
+		endpointReq.URL.Path = endpoint.Path + strings.TrimPrefix(req.URL.Path, primary.Path)
+		endpointReq.URL.RawPath = ""
+		endpointReq.Host = ""
+		if body != nil {
+			endpointReq.Body = io.NopCloser(bytes.NewReader(body))
+			endpointReq.ContentLength = int64(len(body))
+		}
+
+		resp, err := t.base.RoundTrip(endpointReq)
+		if err != nil {
+			if req.Context().Err() != nil {
+				return nil, err
+			}
+			t.pool.ReportFailure(addr, err)
+			lastErr = err
+			continue
+		}
+
+		if !isFailoverStatus(resp.StatusCode) {
+			t.pool.ReportSuccess(addr)
+			return resp, nil
+		}
+
+		t.pool.ReportFailure(addr, errors.Errorf("http status %s", resp.Status))
+		if i == len(addrs)-1 {
+			// Nothing left to fail over to, so the caller gets the response as is
+			return resp, nil
+		}
+		io.Copy(io.Discard, resp.Body) //nolint:errcheck
+		resp.Body.Close()
+	}
+
+	return nil, errors.Wrapf(lastErr, "all %s endpoints failed", t.pool.Name())
+}
+
+func isFailoverStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// endpointURL parses an http endpoint, where tcp:// (as used for CometBFT RPC addresses) means http://.
+func endpointURL(addr string) (*url.URL, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid endpoint %s", addr)
+	}
+	if u.Scheme == "tcp" {
+		u.Scheme = "http"
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("endpoint %s is not an http(s) url", addr)
+	}
+
+	return u, nil
+}
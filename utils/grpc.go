@@ -1,80 +1,193 @@
 package utils
 
 import (
-	"fmt"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
 	"strings"
 
+	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
+// grpcRetryPolicy retries calls that failed because the server was unavailable or rate limited us, with backoff.
+// Other codes (e.g. NOT_FOUND or PERMISSION_DENIED) are answers from the server, which retrying won't change.
+const grpcRetryPolicy = `{
+	"methodConfig": [{
+		"name": [{}],
+		"retryPolicy": {
+			"maxAttempts": 4,
+			"initialBackoff": "0.2s",
+			"maxBackoff": "2s",
+			"backoffMultiplier": 2.0,
+			"retryableStatusCodes": ["UNAVAILABLE", "RESOURCE_EXHAUSTED"]
+		}
+	}]
+}`
+
+// GRPCConfig configures the transport used to connect to gRPC endpoints.
+//
+// An address can pick TLS with its scheme: https:// or grpcs:// for TLS, and http:// or grpc:// for plaintext.
+// Addresses without a scheme use TLS if it is enabled here. Since those on port 443 used to get TLS regardless,
+// they are refused if TLS is not enabled, instead of silently connecting in plaintext.
+type GRPCConfig struct {
+	// TLS enables TLS for addresses without a scheme. It is implied by any of the files below.
+	TLS bool
+	// CAFile is a PEM file with the CA certificates to verify the server with, instead of the system ones
+	CAFile string
+	// CertFile and KeyFile are the PEM client certificate and key, for servers that require mutual TLS
+	CertFile string
+	KeyFile  string
+	// ServerName overrides the name the server certificate is verified against
+	ServerName string
+	// Headers are sent as metadata with every call, e.g. the API key of a provider.
+	// They are only sent over TLS, unless InsecureHeaders allows plaintext (e.g. for a local node).
+	Headers         map[string]string
+	InsecureHeaders bool
+}
+
+// GetGRPC connects to the address with the default transport config, i.e. TLS only if the address has a TLS scheme.
 func GetGRPC(addr string) (*grpc.ClientConn, error) {
-	if strings.Contains(addr, "443") {
-		return getTLSGRPC(addr)
-	} else {
-		return getNonTLSGRPC(addr)
+	return GRPCConfig{}.dial(addr, nil)
+}
+
+// Dialer returns a function that connects to gRPC addresses using the config.
+// The certificate files are read here, so any problem with them is returned right away instead of on every dial.
+func (c GRPCConfig) Dialer() (func(addr string) (*grpc.ClientConn, error), error) {
+	if c.CertFile != "" || c.KeyFile != "" || c.CAFile != "" {
+		c.TLS = true
 	}
+
+	var tlsConfig *tls.Config
+	if c.TLS {
+		var err error
+		tlsConfig, err = c.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return func(addr string) (*grpc.ClientConn, error) {
+		return c.dial(addr, tlsConfig)
+	}, nil
 }
 
-func getTLSGRPC(addr string) (*grpc.ClientConn, error) {
-	creds := credentials.NewTLS(nil)
+// dial connects to the address, using TLS with the tls config if the address doesn't ask for plaintext with its scheme.
+// A nil tls config means plaintext, unless the address asks for TLS, in which case the system CAs are used.
+func (c GRPCConfig) dial(addr string, tlsConfig *tls.Config) (*grpc.ClientConn, error) {
+	target, useTLS, hasScheme := grpcTarget(addr)
+	if !hasScheme {
+		useTLS = tlsConfig != nil
+
+		// Port 443 used to mean TLS, so rather than failing later with an opaque transport error, ask for the TLS to be explicit
+		if !useTLS && strings.HasSuffix(target, ":443") {
+			return nil, errors.Errorf("grpc addr %s is on port 443 but would use plaintext: use https://%s (or enable grpc-tls for the chain) for TLS, or http://%s for plaintext", addr, addr, addr)
+		}
+	}
 
-	// Establish a secure connection with the gRPC server
-	conn, err := grpc.Dial(
-		addr,
-		grpc.WithTransportCredentials(creds),
-		retryConfig(),
-	)
+	if len(c.Headers) > 0 && !useTLS && !c.InsecureHeaders {
+		return nil, errors.Errorf("grpc addr %s uses plaintext, which would send the grpc headers (e.g. an API key) in cleartext: use TLS, or allow insecure headers", addr)
+	}
+
+	transportCreds := insecure.NewCredentials()
+	if useTLS {
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12, ServerName: c.ServerName}
+		}
+		transportCreds = credentials.NewTLS(tlsConfig)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(transportCreds),
+		grpc.WithDefaultServiceConfig(grpcRetryPolicy),
+	}
+	if len(c.Headers) > 0 {
+		opts = append(opts, grpc.WithPerRPCCredentials(headerCredentials{headers: c.Headers, requireTLS: useTLS}))
+	}
+
+	conn, err := grpc.NewClient(target, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to grpc client with addr: %s: %w", addr, err)
+		return nil, errors.Wrapf(err, "failed to connect to grpc client with addr: %s", addr)
 	}
 
 	return conn, nil
 }
 
-func getNonTLSGRPC(addr string) (*grpc.ClientConn, error) {
-	// Establish a connection with the gRPC server
-	conn, err := grpc.Dial(
-		addr,
-		grpc.WithInsecure(),
-		retryConfig(),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to grpc client with addr: %s: %w", addr, err)
+func (c GRPCConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: c.ServerName,
 	}
 
-	return conn, nil
+	if c.CAFile != "" {
+		caPEM, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read grpc ca file %s", c.CAFile)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.Errorf("no certificates found in grpc ca file %s", c.CAFile)
+		}
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		if c.CertFile == "" || c.KeyFile == "" {
+			return nil, errors.New("both a grpc client cert file and key file are needed for mutual TLS")
+		}
+
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load grpc client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// grpcTarget strips the scheme from the address, and returns whether the scheme asks for TLS (if it has one).
+func grpcTarget(addr string) (target string, useTLS bool, hasScheme bool) {
+	scheme, target, ok := strings.Cut(addr, "://")
+	if !ok {
+		return addr, false, false
+	}
+
+	// Drop any path, which gRPC has no use for
+	target, _, _ = strings.Cut(target, "/")
+
+	switch strings.ToLower(scheme) {
+	case "https", "grpcs":
+		return target, true, true
+	case "http", "grpc":
+		return target, false, true
+	default:
+		// Not a scheme we know, so leave the address as it is for the gRPC resolver (e.g. dns:// or unix://)
+		return addr, false, false
+	}
+}
+
+// headerCredentials sends the headers as metadata with every call
+type headerCredentials struct {
+	headers    map[string]string
+	requireTLS bool
+}
+
+var _ credentials.PerRPCCredentials = headerCredentials{}
+
+func (h headerCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	metadata := make(map[string]string, len(h.headers))
+	for key, value := range h.headers {
+		// gRPC metadata keys must be lowercase
+		metadata[strings.ToLower(key)] = value
+	}
+
+	return metadata, nil
 }
 
-func retryConfig() grpc.DialOption {
-	policy := `{
-            "methodConfig": [{
-                "name": [{}],
-                "retryPolicy": {
-                    "MaxAttempts": 4,
-                    "InitialBackoff": ".01s",
-                    "MaxBackoff": ".01s",
-                    "BackoffMultiplier": 1.0,
-                    "RetryableStatusCodes": [
-						"CANCELLED",
-						"UNKNOWN",
-						"DEADLINE_EXCEEDED",
-						"NOT_FOUND",
-						"ALREADY_EXISTS",
-						"PERMISSION_DENIED",
-						"RESOURCE_EXHAUSTED",
-						"FAILED_PRECONDITION",
-						"ABORTED",
-						"OUT_OF_RANGE",
-						"UNIMPLEMENTED",
-						"INTERNAL",
-						"UNAVAILABLE",
-						"DATA_LOSS",
-						"UNAUTHENTICATED"
-				    ]
-                }
-            }]
-        }`
-
-	return grpc.WithDefaultServiceConfig(policy)
+func (h headerCredentials) RequireTransportSecurity() bool {
+	return h.requireTLS
 }
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGRPCTarget(t *testing.T) {
+	tests := []struct {
+		addr      string
+		target    string
+		useTLS    bool
+		hasScheme bool
+	}{
+		{addr: "localhost:9090", target: "localhost:9090"},
+		{addr: "grpc.example.com:443", target: "grpc.example.com:443"},
+		{addr: "https://grpc.example.com:443", target: "grpc.example.com:443", useTLS: true, hasScheme: true},
+		{addr: "grpcs://grpc.example.com", target: "grpc.example.com", useTLS: true, hasScheme: true},
+		{addr: "http://localhost:9090/", target: "localhost:9090", hasScheme: true},
+		{addr: "grpc://localhost:9090", target: "localhost:9090", hasScheme: true},
+		{addr: "dns:///grpc.example.com:443", target: "dns:///grpc.example.com:443"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			target, useTLS, hasScheme := grpcTarget(tt.addr)
+			require.Equal(t, tt.target, target)
+			require.Equal(t, tt.useTLS, useTLS)
+			require.Equal(t, tt.hasScheme, hasScheme)
+		})
+	}
+}
+
+func TestGRPCConfigDialer(t *testing.T) {
+	dial, err := GRPCConfig{TLS: true, Headers: map[string]string{"X-API-Key": "secret"}}.Dialer()
+	require.NoError(t, err)
+	conn, err := dial("localhost:9090")
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	_, err = GRPCConfig{CertFile: "client.pem"}.Dialer()
+	require.ErrorContains(t, err, "key file")
+
+	_, err = GRPCConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")}.Dialer()
+	require.Error(t, err)
+
+	// Port 443 without a scheme or TLS enabled used to mean TLS, so it has to be made explicit
+	_, err = GetGRPC("grpc.example.com:443")
+	require.ErrorContains(t, err, "https://grpc.example.com:443")
+	for _, addr := range []string{"https://grpc.example.com:443", "http://grpc.example.com:443"} {
+		conn, err := GetGRPC(addr)
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+	}
+
+	// Headers are not sent in cleartext unless that is explicitly allowed
+	dial, err = GRPCConfig{Headers: map[string]string{"X-API-Key": "secret"}}.Dialer()
+	require.NoError(t, err)
+	_, err = dial("localhost:9090")
+	require.ErrorContains(t, err, "cleartext")
+	conn, err = dial("https://localhost:9090")
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	dial, err = GRPCConfig{Headers: map[string]string{"X-API-Key": "secret"}, InsecureHeaders: true}.Dialer()
+	require.NoError(t, err)
+	conn, err = dial("localhost:9090")
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+	_, err = GRPCConfig{CAFile: caFile}.Dialer()
+	require.ErrorContains(t, err, "no certificates")
+}
+
+func TestHeaderCredentials(t *testing.T) {
+	creds := headerCredentials{headers: map[string]string{"X-API-Key": "secret"}}
+	metadata, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"x-api-key": "secret"}, metadata)
+	require.False(t, creds.RequireTransportSecurity())
+}
@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/pkg/errors"
+)
+
+// jwtSecretLength is the length of the HS256 secret used by execution clients for authenticated RPC
+const jwtSecretLength = 32
+
+// HTTPAuth is the authentication sent with every request to an HTTP endpoint, for providers that need an API key or a JWT.
+// At most one of BearerToken, JWTSecret and basic auth can be set, as they all use the Authorization header.
+type HTTPAuth struct {
+	// Headers are set on every request, e.g. an API key header
+	Headers map[string]string
+	// BearerToken is sent as "Authorization: Bearer <token>", e.g. a JWT issued by the provider
+	BearerToken string
+	// JWTSecret is a hex encoded 32 byte secret used to sign a fresh HS256 JWT for every request,
+	// as done by the authenticated RPC of execution clients
+	JWTSecret string
+	// BasicAuthUser and BasicAuthPassword are sent as basic auth
+	BasicAuthUser     string
+	BasicAuthPassword string
+}
+
+// Validate checks that at most one kind of authorization is set and that the JWT secret is valid.
+func (a HTTPAuth) Validate() error {
+	authorizations := 0
+	if a.BearerToken != "" {
+		authorizations++
+	}
+	if a.JWTSecret != "" {
+		authorizations++
+		if _, err := a.jwtSecret(); err != nil {
+			return err
+		}
+	}
+	if a.BasicAuthUser != "" || a.BasicAuthPassword != "" {
+		authorizations++
+	}
+	if authorizations > 1 {
+		return errors.New("only one of bearer token, jwt secret and basic auth can be set")
+	}
+
+	return nil
+}
+
+// IsZero returns true if no authentication is configured.
+func (a HTTPAuth) IsZero() bool {
+	return len(a.Headers) == 0 && a.BearerToken == "" && a.JWTSecret == "" && a.BasicAuthUser == "" && a.BasicAuthPassword == ""
+}
+
+// SetHeaders sets the authentication headers. It matches go-ethereum's rpc.HTTPAuth, so it can be used with rpc.WithHTTPAuth.
+func (a HTTPAuth) SetHeaders(header http.Header) error {
+	for key, value := range a.Headers {
+		header.Set(key, value)
+	}
+
+	switch {
+	case a.BearerToken != "":
+		header.Set("Authorization", "Bearer "+a.BearerToken)
+	case a.JWTSecret != "":
+		secret, err := a.jwtSecret()
+		if err != nil {
+			return err
+		}
+		if err := node.NewJWTAuth([jwtSecretLength]byte(secret))(header); err != nil {
+			return errors.Wrap(err, "failed to sign jwt")
+		}
+	case a.BasicAuthUser != "" || a.BasicAuthPassword != "":
+		credentials := base64.StdEncoding.EncodeToString([]byte(a.BasicAuthUser + ":" + a.BasicAuthPassword))
+		header.Set("Authorization", "Basic "+credentials)
+	}
+
+	return nil
+}
+
+// Transport returns an http.RoundTripper that adds the authentication to every request.
+// If base is nil, http.DefaultTransport is used.
+func (a HTTPAuth) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &authTransport{auth: a, base: base}
+}
+
+type authTransport struct {
+	auth HTTPAuth
+	base http.RoundTripper
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request, so the headers are set on a clone
+	authReq := req.Clone(req.Context())
+	if err := t.auth.SetHeaders(authReq.Header); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	return t.base.RoundTrip(authReq)
+}
+
+func (a HTTPAuth) jwtSecret() ([]byte, error) {
+	secret, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(a.JWTSecret), "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid jwt secret, must be hex encoded")
+	}
+	if len(secret) != jwtSecretLength {
+		return nil, errors.Errorf("invalid jwt secret length %d, must be %d bytes", len(secret), jwtSecretLength)
+	}
+
+	return secret, nil
+}
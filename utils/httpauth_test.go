@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testJWTSecret = "0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+func TestHTTPAuthValidate(t *testing.T) {
+	require.NoError(t, HTTPAuth{}.Validate())
+	require.NoError(t, HTTPAuth{Headers: map[string]string{"X-API-Key": "key"}, BearerToken: "token"}.Validate())
+	require.NoError(t, HTTPAuth{JWTSecret: testJWTSecret}.Validate())
+
+	require.Error(t, HTTPAuth{BearerToken: "token", BasicAuthUser: "user"}.Validate())
+	require.Error(t, HTTPAuth{JWTSecret: "not hex"}.Validate())
+	require.Error(t, HTTPAuth{JWTSecret: "0x0102"}.Validate())
+}
+
+func TestHTTPAuthSetHeaders(t *testing.T) {
+	header := http.Header{}
+	require.NoError(t, HTTPAuth{Headers: map[string]string{"X-API-Key": "key"}, BearerToken: "token"}.SetHeaders(header))
+	require.Equal(t, "key", header.Get("X-API-Key"))
+	require.Equal(t, "Bearer token", header.Get("Authorization"))
+
+	header = http.Header{}
+	require.NoError(t, HTTPAuth{BasicAuthUser: "user", BasicAuthPassword: "pass"}.SetHeaders(header))
+	req := &http.Request{Header: header}
+	user, pass, ok := req.BasicAuth()
+	require.True(t, ok)
+	require.Equal(t, "user", user)
+	require.Equal(t, "pass", pass)
+
+	header = http.Header{}
+	require.NoError(t, HTTPAuth{JWTSecret: testJWTSecret}.SetHeaders(header))
+	require.True(t, strings.HasPrefix(header.Get("Authorization"), "Bearer "))
+}
+
+func TestHTTPAuthTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	client := &http.Client{Transport: HTTPAuth{BearerToken: "token"}.Transport(nil)}
+	resp, err = client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -10,12 +10,20 @@ import (
 // The function fn should return true of the desired condition is met. If the function never returns true within the timeoutAfter
 // period, or fn returns an error, the condition will not have been met.
 func WaitForCondition(timeoutAfter, pollingInterval time.Duration, fn func() (bool, error)) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutAfter)
+	return WaitForConditionWithContext(context.Background(), timeoutAfter, pollingInterval, fn)
+}
+
+// WaitForConditionWithContext is WaitForCondition, but also stops waiting when the context is done.
+func WaitForConditionWithContext(ctx context.Context, timeoutAfter, pollingInterval time.Duration, fn func() (bool, error)) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeoutAfter)
 	defer cancel()
 
 	for {
 		select {
-		case <-ctx.Done():
+		case <-timeoutCtx.Done():
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("stopped waiting for condition: %w", err)
+			}
 			return fmt.Errorf("failed waiting for condition after %f seconds", timeoutAfter.Seconds())
 		case <-time.After(pollingInterval):
 			reachedCondition, err := fn()
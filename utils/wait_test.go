@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForConditionWithContext(t *testing.T) {
+	calls := 0
+	require.NoError(t, WaitForConditionWithContext(context.Background(), time.Second, time.Millisecond, func() (bool, error) {
+		calls++
+		return calls == 3, nil
+	}))
+	require.Equal(t, 3, calls)
+
+	err := WaitForConditionWithContext(context.Background(), 20*time.Millisecond, time.Millisecond, func() (bool, error) {
+		return false, nil
+	})
+	require.ErrorContains(t, err, "failed waiting for condition")
+
+	// A cancelled context stops the wait before the timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = WaitForConditionWithContext(ctx, time.Hour, time.Millisecond, func() (bool, error) {
+		return false, nil
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second)
+}